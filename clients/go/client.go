@@ -3,6 +3,7 @@ package merklekv
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"strings"
@@ -10,18 +11,64 @@ import (
 	"time"
 )
 
+// ClientOptions configures optional reconnect behavior for Client.
+type ClientOptions struct {
+	// MaxReconnects bounds how many additional dial attempts Connect makes
+	// after the first one fails. Zero means no retries.
+	MaxReconnects int
+	// ReconnectDelay is the base delay before the first retry. Defaults to 50ms.
+	ReconnectDelay time.Duration
+	// MaxReconnectDelay caps the exponentially increasing delay. Defaults to 2s.
+	MaxReconnectDelay time.Duration
+	// Jitter randomizes each delay by +/- this fraction (0..1). Defaults to 0.2.
+	Jitter float64
+	// Dialer, if set, replaces the plain net.Dialer used to establish the
+	// connection (e.g. a TLSDialer for TLS/mTLS transport).
+	Dialer Dialer
+	// Interceptors wraps every sendCommand call in the given order (the
+	// first interceptor is outermost), letting callers compose cross-cutting
+	// behavior like RetryInterceptor, CircuitBreakerInterceptor, and
+	// MetricsInterceptor without patching the client.
+	Interceptors []Interceptor
+	// BatchInterceptors is Interceptors' Pipeline analogue.
+	BatchInterceptors []BatchInterceptor
+}
+
+func (o ClientOptions) withDefaults() ClientOptions {
+	if o.ReconnectDelay <= 0 {
+		o.ReconnectDelay = 50 * time.Millisecond
+	}
+	if o.MaxReconnectDelay <= 0 {
+		o.MaxReconnectDelay = 2 * time.Second
+	}
+	if o.Jitter <= 0 {
+		o.Jitter = 0.2
+	}
+	return o
+}
+
+func (o ClientOptions) backoff() BackoffPolicy {
+	return ExponentialBackoff{Base: o.ReconnectDelay, Max: o.MaxReconnectDelay, Jitter: o.Jitter}
+}
+
 // Client represents a connection to a MerkleKV server.
 type Client struct {
 	host    string
 	port    int
 	timeout time.Duration
+	opts    ClientOptions
 
 	// Connection state
-	mu       sync.RWMutex
-	conn     net.Conn
-	reader   *bufio.Reader
-	writer   *bufio.Writer
-	connected bool
+	mu            sync.RWMutex
+	conn          net.Conn
+	reader        *bufio.Reader
+	writer        *bufio.Writer
+	connected     bool
+	everConnected bool
+
+	// watchMu guards the lazily-created, shared Watch connection.
+	watchMu   sync.Mutex
+	watchConn *watchBroadcaster
 }
 
 // New creates a new MerkleKV client.
@@ -36,6 +83,7 @@ func New(host string, port int) *Client {
 		host:    host,
 		port:    port,
 		timeout: 5 * time.Second,
+		opts:    ClientOptions{}.withDefaults(),
 	}
 }
 
@@ -52,6 +100,25 @@ func NewWithTimeout(host string, port int, timeout time.Duration) *Client {
 		host:    host,
 		port:    port,
 		timeout: timeout,
+		opts:    ClientOptions{}.withDefaults(),
+	}
+}
+
+// NewWithOptions creates a new MerkleKV client with custom reconnect behavior.
+//
+// Parameters:
+//   - host: Server hostname (e.g., "localhost")
+//   - port: Server port (e.g., 7379)
+//   - opts: Reconnect policy applied by Connect and by transparent
+//     reconnection inside sendCommand/PipelineWithContext
+//
+// Returns a new Client instance with the given options.
+func NewWithOptions(host string, port int, opts ClientOptions) *Client {
+	return &Client{
+		host:    host,
+		port:    port,
+		timeout: 5 * time.Second,
+		opts:    opts.withDefaults(),
 	}
 }
 
@@ -62,26 +129,80 @@ func (c *Client) Connect() error {
 	return c.ConnectWithContext(context.Background())
 }
 
-// ConnectWithContext establishes a connection to the MerkleKV server with context.
-//
-// The context can be used to cancel the connection attempt or set a timeout.
+// ConnectWithContext establishes a connection to the MerkleKV server with
+// context, retrying transient dial failures with exponential backoff and
+// jitter up to ClientOptions.MaxReconnects times. The context always wins:
+// if it is done before the retry budget is exhausted, that error is
+// returned immediately and unwrapped (mirroring the pattern used by
+// tarantool-go's connection.Connect).
 //
 // Returns an error if connection fails or context is canceled.
 func (c *Client) ConnectWithContext(ctx context.Context) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	return c.connectLocked(ctx)
+}
 
+// connectLocked is ConnectWithContext's implementation, assuming c.mu is
+// already held. It is also used by sendCommand/PipelineWithContext to
+// transparently reconnect a connection that dropped mid-use.
+func (c *Client) connectLocked(ctx context.Context) error {
 	if c.connected && c.conn != nil {
 		return nil // Already connected
 	}
 
-	// Create a dialer with timeout
-	dialer := &net.Dialer{
-		Timeout: c.timeout,
+	backoff := c.opts.backoff()
+	attempts := c.opts.MaxReconnects + 1
+
+	var errs []error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if attempt > 0 {
+			timer := time.NewTimer(backoff.Next(attempt - 1))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		err := c.dialOnceLocked(ctx)
+		if err == nil {
+			return nil
+		}
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			return err
+		}
+		errs = append(errs, err)
 	}
 
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return &ConnectionError{Op: "connect", Err: errors.Join(errs...)}
+}
+
+// dialOnceLocked performs a single dial attempt, assuming c.mu is held. If
+// opts.Dialer is set (e.g. a TLSDialer), it is used in place of a plain TCP
+// dial; TCP_NODELAY is then the dialer's responsibility.
+func (c *Client) dialOnceLocked(ctx context.Context) error {
 	address := fmt.Sprintf("%s:%d", c.host, c.port)
-	conn, err := dialer.DialContext(ctx, "tcp", address)
+
+	var conn net.Conn
+	var err error
+	if c.opts.Dialer != nil {
+		conn, err = c.opts.Dialer.DialContext(ctx, "tcp", address)
+	} else {
+		dialer := &net.Dialer{Timeout: c.timeout}
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	}
 	if err != nil {
 		return &ConnectionError{Op: "connect", Err: err}
 	}
@@ -98,12 +219,21 @@ func (c *Client) ConnectWithContext(ctx context.Context) error {
 	c.reader = bufio.NewReader(conn)
 	c.writer = bufio.NewWriter(conn)
 	c.connected = true
+	c.everConnected = true
 
 	return nil
 }
 
-// Close closes the connection to the server.
+// Close closes the connection to the server, along with the dedicated Watch
+// connection if one was opened.
 func (c *Client) Close() error {
+	c.watchMu.Lock()
+	if c.watchConn != nil {
+		c.watchConn.close()
+		c.watchConn = nil
+	}
+	c.watchMu.Unlock()
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -127,13 +257,28 @@ func (c *Client) IsConnected() bool {
 	return c.connected && c.conn != nil
 }
 
-// sendCommand sends a command to the server and returns the response.
+// sendCommand sends a command to the server and returns the response,
+// running it through any configured ClientOptions.Interceptors.
 func (c *Client) sendCommand(ctx context.Context, command string) (string, error) {
+	return chainInterceptors(c.opts.Interceptors, c.doSendCommand)(ctx, command)
+}
+
+// doSendCommand is sendCommand's innermost Invoker: it sends command over
+// the wire and returns the response. If a prior command left the connection
+// broken, it transparently reconnects using the client's configured backoff
+// policy before sending; a client that has never been connected still fails
+// fast with ErrNotConnected.
+func (c *Client) doSendCommand(ctx context.Context, command string) (string, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	if !c.connected || c.conn == nil {
-		return "", ErrNotConnected
+		if !c.everConnected {
+			return "", fmt.Errorf("send command: %w", ErrNotConnected)
+		}
+		if err := c.connectLocked(ctx); err != nil {
+			return "", fmt.Errorf("send command (reconnect): %w", err)
+		}
 	}
 
 	// Set deadline based on context or timeout
@@ -165,7 +310,7 @@ func (c *Client) sendCommand(ctx context.Context, command string) (string, error
 	if err != nil {
 		c.connected = false
 		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
-			return "", &TimeoutError{Op: "read response", Timeout: c.timeout.String()}
+			return "", &TimeoutError{Op: "read response", Timeout: c.timeout.String(), Err: err}
 		}
 		return "", &ConnectionError{Op: "read", Err: err}
 	}
@@ -202,19 +347,20 @@ func (c *Client) Get(key string) (string, error) {
 //
 // The context can be used to cancel the operation or set a timeout.
 //
-// Returns the value if the key exists, or ErrNotFound if the key doesn't exist.
+// Returns the value if the key exists, or an error wrapping ErrNotFound
+// (checkable with errors.Is) if the key doesn't exist.
 func (c *Client) GetWithContext(ctx context.Context, key string) (string, error) {
 	if key == "" {
-		return "", ErrEmptyKey
+		return "", fmt.Errorf("get: %w", ErrEmptyKey)
 	}
 
 	response, err := c.sendCommand(ctx, fmt.Sprintf("GET %s", key))
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("get %q: %w", key, err)
 	}
 
 	if response == "NOT_FOUND" {
-		return "", ErrNotFound
+		return "", fmt.Errorf("get %q: %w", key, ErrNotFound)
 	}
 
 	if strings.HasPrefix(response, "VALUE ") {
@@ -238,7 +384,7 @@ func (c *Client) Set(key, value string) error {
 // Returns an error if the operation fails.
 func (c *Client) SetWithContext(ctx context.Context, key, value string) error {
 	if key == "" {
-		return ErrEmptyKey
+		return fmt.Errorf("set: %w", ErrEmptyKey)
 	}
 
 	// Format the command using the helper function
@@ -246,7 +392,7 @@ func (c *Client) SetWithContext(ctx context.Context, key, value string) error {
 
 	response, err := c.sendCommand(ctx, command)
 	if err != nil {
-		return err
+		return fmt.Errorf("set %q: %w", key, err)
 	}
 
 	if response != "OK" {
@@ -272,12 +418,12 @@ func (c *Client) Delete(key string) error {
 // key is not considered an error and will return nil.
 func (c *Client) DeleteWithContext(ctx context.Context, key string) error {
 	if key == "" {
-		return ErrEmptyKey
+		return fmt.Errorf("delete: %w", ErrEmptyKey)
 	}
 
 	response, err := c.sendCommand(ctx, fmt.Sprintf("DELETE %s", key))
 	if err != nil {
-		return err
+		return fmt.Errorf("delete %q: %w", key, err)
 	}
 
 	switch response {
@@ -307,7 +453,7 @@ func (c *Client) Ping() error {
 func (c *Client) PingWithContext(ctx context.Context) error {
 	response, err := c.sendCommand(ctx, "PING")
 	if err != nil {
-		return err
+		return fmt.Errorf("ping: %w", err)
 	}
 
 	if response != "PONG" && response != "OK" {
@@ -337,16 +483,26 @@ func (c *Client) Pipeline(commands []string) ([]string, error) {
 //   - commands: Slice of command strings to execute
 //
 // Returns a slice of response strings in the same order as input commands.
+// The batch is run through any configured ClientOptions.BatchInterceptors.
 func (c *Client) PipelineWithContext(ctx context.Context, commands []string) ([]string, error) {
 	if len(commands) == 0 {
 		return []string{}, nil
 	}
+	return chainBatchInterceptors(c.opts.BatchInterceptors, c.doPipeline)(ctx, commands)
+}
 
+// doPipeline is PipelineWithContext's innermost BatchInvoker.
+func (c *Client) doPipeline(ctx context.Context, commands []string) ([]string, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
 	if !c.connected || c.conn == nil {
-		return nil, ErrNotConnected
+		if !c.everConnected {
+			return nil, fmt.Errorf("pipeline: %w", ErrNotConnected)
+		}
+		if err := c.connectLocked(ctx); err != nil {
+			return nil, fmt.Errorf("pipeline (reconnect): %w", err)
+		}
 	}
 
 	// Set deadline based on context or timeout
@@ -383,7 +539,7 @@ func (c *Client) PipelineWithContext(ctx context.Context, commands []string) ([]
 		if err != nil {
 			c.connected = false
 			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
-				return nil, &TimeoutError{Op: "read pipeline response", Timeout: c.timeout.String()}
+				return nil, &TimeoutError{Op: "read pipeline response", Timeout: c.timeout.String(), Err: err}
 			}
 			return nil, &ConnectionError{Op: "read pipeline", Err: err}
 		}
@@ -423,12 +579,11 @@ func (c *Client) HealthCheckWithContext(ctx context.Context) (bool, error) {
 	response, err := c.sendCommand(ctx, "GET __health__")
 	if err != nil {
 		// Check if it's a protocol error indicating NOT_FOUND
-		if protocolErr, ok := err.(*ProtocolError); ok {
-			if strings.Contains(protocolErr.Message, "NOT_FOUND") {
-				return true, nil // NOT_FOUND is considered healthy
-			}
+		var protocolErr *ProtocolError
+		if errors.As(err, &protocolErr) && strings.Contains(protocolErr.Message, "NOT_FOUND") {
+			return true, nil // NOT_FOUND is considered healthy
 		}
-		return false, err
+		return false, fmt.Errorf("health check: %w", err)
 	}
 
 	// Any successful response indicates health
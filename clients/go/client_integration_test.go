@@ -34,14 +34,14 @@ func TestIntegrationBasicOperations(t *testing.T) {
 	
 	// Test GET non-existent key
 	_, err = client.Get("nonexistent_key")
-	assert.Equal(t, ErrNotFound, err)
+	assert.ErrorIs(t, err, ErrNotFound)
 	
 	// Test DELETE
 	err = client.Delete("test_key")
 	require.NoError(t, err)
 	
 	_, err = client.Get("test_key")
-	assert.Equal(t, ErrNotFound, err)
+	assert.ErrorIs(t, err, ErrNotFound)
 	
 	// Test empty value
 	err = client.Set("empty_key", "")
@@ -67,7 +67,7 @@ func TestGetEmptyKey(t *testing.T) {
 	client := New("localhost", 7379)
 	
 	_, err := client.Get("")
-	assert.Equal(t, ErrEmptyKey, err)
+	assert.ErrorIs(t, err, ErrEmptyKey)
 }
 
 func TestGetWithContextEmptyKey(t *testing.T) {
@@ -75,14 +75,14 @@ func TestGetWithContextEmptyKey(t *testing.T) {
 	ctx := context.Background()
 	
 	_, err := client.GetWithContext(ctx, "")
-	assert.Equal(t, ErrEmptyKey, err)
+	assert.ErrorIs(t, err, ErrEmptyKey)
 }
 
 func TestSetEmptyKey(t *testing.T) {
 	client := New("localhost", 7379)
 	
 	err := client.Set("", "value")
-	assert.Equal(t, ErrEmptyKey, err)
+	assert.ErrorIs(t, err, ErrEmptyKey)
 }
 
 func TestSetWithContextEmptyKey(t *testing.T) {
@@ -90,14 +90,14 @@ func TestSetWithContextEmptyKey(t *testing.T) {
 	ctx := context.Background()
 	
 	err := client.SetWithContext(ctx, "", "value")
-	assert.Equal(t, ErrEmptyKey, err)
+	assert.ErrorIs(t, err, ErrEmptyKey)
 }
 
 func TestDeleteEmptyKey(t *testing.T) {
 	client := New("localhost", 7379)
 	
 	err := client.Delete("")
-	assert.Equal(t, ErrEmptyKey, err)
+	assert.ErrorIs(t, err, ErrEmptyKey)
 }
 
 func TestDeleteWithContextEmptyKey(t *testing.T) {
@@ -105,7 +105,7 @@ func TestDeleteWithContextEmptyKey(t *testing.T) {
 	ctx := context.Background()
 	
 	err := client.DeleteWithContext(ctx, "")
-	assert.Equal(t, ErrEmptyKey, err)
+	assert.ErrorIs(t, err, ErrEmptyKey)
 }
 
 func TestOperationsNotConnected(t *testing.T) {
@@ -113,16 +113,16 @@ func TestOperationsNotConnected(t *testing.T) {
 	
 	// Test all operations fail when not connected
 	_, err := client.Get("key")
-	assert.Equal(t, ErrNotConnected, err)
+	assert.ErrorIs(t, err, ErrNotConnected)
 	
 	err = client.Set("key", "value")
-	assert.Equal(t, ErrNotConnected, err)
+	assert.ErrorIs(t, err, ErrNotConnected)
 	
 	err = client.Delete("key")
-	assert.Equal(t, ErrNotConnected, err)
+	assert.ErrorIs(t, err, ErrNotConnected)
 	
 	err = client.Ping()
-	assert.Equal(t, ErrNotConnected, err)
+	assert.ErrorIs(t, err, ErrNotConnected)
 }
 
 func TestContextOperationsNotConnected(t *testing.T) {
@@ -131,16 +131,16 @@ func TestContextOperationsNotConnected(t *testing.T) {
 	
 	// Test all context operations fail when not connected
 	_, err := client.GetWithContext(ctx, "key")
-	assert.Equal(t, ErrNotConnected, err)
+	assert.ErrorIs(t, err, ErrNotConnected)
 	
 	err = client.SetWithContext(ctx, "key", "value")
-	assert.Equal(t, ErrNotConnected, err)
+	assert.ErrorIs(t, err, ErrNotConnected)
 	
 	err = client.DeleteWithContext(ctx, "key")
-	assert.Equal(t, ErrNotConnected, err)
+	assert.ErrorIs(t, err, ErrNotConnected)
 	
 	err = client.PingWithContext(ctx)
-	assert.Equal(t, ErrNotConnected, err)
+	assert.ErrorIs(t, err, ErrNotConnected)
 }
 
 func TestConnectionError(t *testing.T) {
@@ -177,7 +177,7 @@ func TestPipelineNotConnected(t *testing.T) {
 	
 	_, err := client.Pipeline([]string{"GET test"})
 	
-	assert.Equal(t, ErrNotConnected, err)
+	assert.ErrorIs(t, err, ErrNotConnected)
 }
 
 // Tests for HealthCheck functionality
@@ -186,7 +186,7 @@ func TestHealthCheckNotConnected(t *testing.T) {
 	
 	_, err := client.HealthCheck()
 	
-	assert.Equal(t, ErrNotConnected, err)
+	assert.ErrorIs(t, err, ErrNotConnected)
 }
 
 // Test TCP_NODELAY is applied (coverage for connection setup)
@@ -0,0 +1,403 @@
+package merklekv
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// EndpointStrategy controls the order in which ClusterClient tries the
+// configured endpoints on each operation.
+type EndpointStrategy int
+
+const (
+	// RoundRobin tries the last known good endpoint first, then the others
+	// in configured order starting just after it and wrapping around.
+	RoundRobin EndpointStrategy = iota
+	// Priority always tries endpoints in the order they were configured,
+	// except that the last known good endpoint is tried first.
+	Priority
+	// Random shuffles the fallback endpoints on every call.
+	Random
+)
+
+// BackoffPolicy computes how long to wait before the next retry attempt.
+// attempt is zero-based: the delay before the second overall try is
+// Next(0), before the third is Next(1), and so on.
+type BackoffPolicy interface {
+	Next(attempt int) time.Duration
+}
+
+// ConstantBackoff waits the same fixed delay before every retry.
+type ConstantBackoff struct {
+	Delay time.Duration
+}
+
+// Next returns the fixed delay regardless of attempt.
+func (b ConstantBackoff) Next(attempt int) time.Duration {
+	return b.Delay
+}
+
+// ExponentialBackoff doubles the delay on every attempt, capped at Max, and
+// randomizes it by +/- Jitter (a fraction between 0 and 1) to avoid
+// thundering-herd retries across clients.
+type ExponentialBackoff struct {
+	Base   time.Duration
+	Max    time.Duration
+	Jitter float64
+}
+
+// Next returns the exponentially increasing, jittered delay for attempt.
+func (b ExponentialBackoff) Next(attempt int) time.Duration {
+	if attempt < 0 {
+		attempt = 0
+	}
+	delay := float64(b.Base) * math.Pow(2, float64(attempt))
+	if b.Max > 0 && delay > float64(b.Max) {
+		delay = float64(b.Max)
+	}
+	if b.Jitter > 0 {
+		delay *= 1 - b.Jitter + rand.Float64()*2*b.Jitter
+	}
+	return time.Duration(delay)
+}
+
+// ClusterOptions configures a ClusterClient.
+type ClusterOptions struct {
+	// Strategy selects the endpoint ordering used on each call. Defaults to RoundRobin.
+	Strategy EndpointStrategy
+	// Backoff is consulted between retry attempts. Defaults to ExponentialBackoff
+	// with a 50ms base, 2s cap, and 0.2 jitter.
+	Backoff BackoffPolicy
+	// MaxAttempts bounds how many endpoints are tried per call. Zero means
+	// try every configured endpoint once.
+	MaxAttempts int
+	// Timeout is applied to each underlying Client. Defaults to 5s.
+	Timeout time.Duration
+}
+
+// ClusterClient is a MerkleKV client that fails over across multiple
+// endpoints, retrying transient errors on the next endpoint with backoff.
+// It is modeled on etcd's httpClusterClient retry loop: every call walks the
+// endpoint list starting from the last known good one, and a canceled or
+// expired ctx always wins over further retries.
+type ClusterClient struct {
+	mu          sync.RWMutex
+	endpoints   []string
+	strategy    EndpointStrategy
+	backoff     BackoffPolicy
+	maxAttempts int
+	timeout     time.Duration
+	lastGood    int
+	clients     map[string]*Client
+	rng         *rand.Rand
+}
+
+// NewCluster creates a ClusterClient with default options.
+func NewCluster(endpoints []string) (*ClusterClient, error) {
+	return NewClusterWithOptions(endpoints, ClusterOptions{})
+}
+
+// NewClusterWithOptions creates a ClusterClient over the given "host:port"
+// endpoints, using the provided options. At least one endpoint is required.
+func NewClusterWithOptions(endpoints []string, opts ClusterOptions) (*ClusterClient, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("merklekv: NewClusterWithOptions requires at least one endpoint")
+	}
+
+	if opts.Backoff == nil {
+		opts.Backoff = ExponentialBackoff{Base: 50 * time.Millisecond, Max: 2 * time.Second, Jitter: 0.2}
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+
+	cc := &ClusterClient{
+		endpoints:   append([]string(nil), endpoints...),
+		strategy:    opts.Strategy,
+		backoff:     opts.Backoff,
+		maxAttempts: opts.MaxAttempts,
+		timeout:     opts.Timeout,
+		clients:     make(map[string]*Client, len(endpoints)),
+		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	return cc, nil
+}
+
+// Endpoints returns the currently configured endpoints.
+func (cc *ClusterClient) Endpoints() []string {
+	cc.mu.RLock()
+	defer cc.mu.RUnlock()
+	return append([]string(nil), cc.endpoints...)
+}
+
+// SetEndpoints replaces the configured endpoints. Clients for endpoints that
+// are no longer present are closed; clients for endpoints that remain are
+// kept so in-flight connections are not disrupted.
+func (cc *ClusterClient) SetEndpoints(endpoints []string) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	keep := make(map[string]bool, len(endpoints))
+	for _, e := range endpoints {
+		keep[e] = true
+	}
+	for endpoint, client := range cc.clients {
+		if !keep[endpoint] {
+			client.Close()
+			delete(cc.clients, endpoint)
+		}
+	}
+
+	cc.endpoints = append([]string(nil), endpoints...)
+	cc.lastGood = 0
+}
+
+// Close closes every underlying per-endpoint connection.
+func (cc *ClusterClient) Close() error {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	var firstErr error
+	for _, client := range cc.clients {
+		if err := client.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (cc *ClusterClient) clientFor(endpoint string) (*Client, error) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if client, ok := cc.clients[endpoint]; ok {
+		return client, nil
+	}
+
+	host, port, err := splitHostPort(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	client := NewWithTimeout(host, port, cc.timeout)
+	cc.clients[endpoint] = client
+	return client, nil
+}
+
+// splitHostPort parses a "host:port" endpoint into its components.
+func splitHostPort(endpoint string) (string, int, error) {
+	host, portStr, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return "", 0, &ProtocolError{Op: "parse endpoint", Message: err.Error()}
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return "", 0, &ProtocolError{Op: "parse endpoint", Message: err.Error()}
+	}
+	return host, port, nil
+}
+
+// endpointOrderLocked returns the indices into cc.endpoints in the order
+// they should be tried, always starting with the last known good endpoint.
+// Callers must hold cc.mu (for reading or writing).
+func (cc *ClusterClient) endpointOrderLocked() []int {
+	n := len(cc.endpoints)
+	order := make([]int, 0, n)
+	order = append(order, cc.lastGood)
+
+	switch cc.strategy {
+	case Random:
+		for _, i := range cc.rng.Perm(n) {
+			if i != cc.lastGood {
+				order = append(order, i)
+			}
+		}
+	default: // RoundRobin, Priority
+		for i := 0; i < n; i++ {
+			idx := i
+			if cc.strategy == RoundRobin {
+				idx = (cc.lastGood + 1 + i) % n
+			}
+			if idx != cc.lastGood {
+				order = append(order, idx)
+			}
+		}
+	}
+	return order
+}
+
+// snapshotOrder computes the failover order and the endpoint list to index
+// it against under a single critical section, so a concurrent SetEndpoints
+// can't shrink cc.endpoints between the two reads and leave the order
+// indexing past the end of the new, shorter slice.
+func (cc *ClusterClient) snapshotOrder() ([]int, []string) {
+	cc.mu.RLock()
+	defer cc.mu.RUnlock()
+	return cc.endpointOrderLocked(), append([]string(nil), cc.endpoints...)
+}
+
+// markGood records endpoint as the last known good one. It re-derives the
+// endpoint's current index under cc.mu rather than trusting the idx a caller
+// observed in its own (possibly stale) snapshotOrder snapshot: if a
+// concurrent SetEndpoints shrank or reordered cc.endpoints in the meantime,
+// storing that stale index verbatim could leave lastGood pointing past the
+// end of the new, shorter slice. If endpoint is no longer configured,
+// lastGood is left unchanged.
+func (cc *ClusterClient) markGood(endpoint string) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	for i, e := range cc.endpoints {
+		if e == endpoint {
+			cc.lastGood = i
+			return
+		}
+	}
+}
+
+// isTransient reports whether err should trigger failover to the next
+// endpoint rather than being returned immediately.
+func isTransient(err error) bool {
+	var connErr *ConnectionError
+	var timeoutErr *TimeoutError
+	var protoErr *ProtocolError
+	return errors.As(err, &connErr) || errors.As(err, &timeoutErr) || errors.As(err, &protoErr)
+}
+
+// doWithFailover runs fn against endpoints in failover order, retrying
+// transient errors with backoff until an endpoint succeeds, a non-transient
+// error is returned, attempts are exhausted, or ctx is done.
+func (cc *ClusterClient) doWithFailover(ctx context.Context, fn func(*Client) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	order, endpoints := cc.snapshotOrder()
+	if cc.maxAttempts > 0 && cc.maxAttempts < len(order) {
+		order = order[:cc.maxAttempts]
+	}
+
+	var lastErr error
+	for attempt, idx := range order {
+		if attempt > 0 {
+			timer := time.NewTimer(cc.backoff.Next(attempt - 1))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		client, err := cc.clientFor(endpoints[idx])
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if err := client.ConnectWithContext(ctx); err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return err
+			}
+			lastErr = err
+			continue
+		}
+
+		err = fn(client)
+		if err == nil {
+			cc.markGood(endpoints[idx])
+			return nil
+		}
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return err
+		}
+		if !isTransient(err) {
+			return err
+		}
+		lastErr = err
+	}
+
+	return lastErr
+}
+
+// Get retrieves the value for a key, failing over across endpoints as needed.
+func (cc *ClusterClient) Get(key string) (string, error) {
+	return cc.GetWithContext(context.Background(), key)
+}
+
+// GetWithContext retrieves the value for a key, failing over across
+// endpoints as needed.
+func (cc *ClusterClient) GetWithContext(ctx context.Context, key string) (string, error) {
+	var value string
+	err := cc.doWithFailover(ctx, func(c *Client) error {
+		v, err := c.GetWithContext(ctx, key)
+		if err != nil {
+			return err
+		}
+		value = v
+		return nil
+	})
+	return value, err
+}
+
+// Set stores a key-value pair, failing over across endpoints as needed.
+func (cc *ClusterClient) Set(key, value string) error {
+	return cc.SetWithContext(context.Background(), key, value)
+}
+
+// SetWithContext stores a key-value pair, failing over across endpoints as needed.
+func (cc *ClusterClient) SetWithContext(ctx context.Context, key, value string) error {
+	return cc.doWithFailover(ctx, func(c *Client) error {
+		return c.SetWithContext(ctx, key, value)
+	})
+}
+
+// Delete removes a key, failing over across endpoints as needed.
+func (cc *ClusterClient) Delete(key string) error {
+	return cc.DeleteWithContext(context.Background(), key)
+}
+
+// DeleteWithContext removes a key, failing over across endpoints as needed.
+func (cc *ClusterClient) DeleteWithContext(ctx context.Context, key string) error {
+	return cc.doWithFailover(ctx, func(c *Client) error {
+		return c.DeleteWithContext(ctx, key)
+	})
+}
+
+// Ping checks connectivity, failing over across endpoints as needed.
+func (cc *ClusterClient) Ping() error {
+	return cc.PingWithContext(context.Background())
+}
+
+// PingWithContext checks connectivity, failing over across endpoints as needed.
+func (cc *ClusterClient) PingWithContext(ctx context.Context) error {
+	return cc.doWithFailover(ctx, func(c *Client) error {
+		return c.PingWithContext(ctx)
+	})
+}
+
+// Pipeline executes multiple commands against a single endpoint, failing
+// over to the next endpoint if the whole batch fails transiently.
+func (cc *ClusterClient) Pipeline(commands []string) ([]string, error) {
+	return cc.PipelineWithContext(context.Background(), commands)
+}
+
+// PipelineWithContext executes multiple commands against a single endpoint,
+// failing over to the next endpoint if the whole batch fails transiently.
+func (cc *ClusterClient) PipelineWithContext(ctx context.Context, commands []string) ([]string, error) {
+	var responses []string
+	err := cc.doWithFailover(ctx, func(c *Client) error {
+		r, err := c.PipelineWithContext(ctx, commands)
+		if err != nil {
+			return err
+		}
+		responses = r
+		return nil
+	})
+	return responses, err
+}
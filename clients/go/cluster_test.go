@@ -0,0 +1,315 @@
+package merklekv
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubServer is a minimal line-protocol server used to drive ClusterClient
+// failover scenarios deterministically, without depending on a real
+// MerkleKV server being reachable.
+type stubServer struct {
+	ln    net.Listener
+	mu    sync.Mutex
+	conns []net.Conn
+}
+
+func newStubServer(t *testing.T) *stubServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &stubServer{ln: ln}
+	go s.serve()
+	return s
+}
+
+func (s *stubServer) addr() string {
+	return s.ln.Addr().String()
+}
+
+// close stops accepting new connections and severs any already accepted, so
+// callers can simulate an endpoint actually going away.
+func (s *stubServer) close() {
+	s.ln.Close()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	s.conns = nil
+}
+
+func (s *stubServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns = append(s.conns, conn)
+		s.mu.Unlock()
+		go s.handle(conn)
+	}
+}
+
+func (s *stubServer) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.TrimSpace(line)
+		switch {
+		case cmd == "PING":
+			conn.Write([]byte("PONG\r\n"))
+		case strings.HasPrefix(cmd, "GET "):
+			conn.Write([]byte("VALUE stub\r\n"))
+		case strings.HasPrefix(cmd, "SET "):
+			conn.Write([]byte("OK\r\n"))
+		default:
+			conn.Write([]byte("ERROR unknown command\r\n"))
+		}
+	}
+}
+
+func TestClusterEndpointRotationOnConnectFailure(t *testing.T) {
+	good := newStubServer(t)
+	defer good.close()
+
+	// A closed listener on an unused port stands in for a dead endpoint.
+	dead, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	deadAddr := dead.Addr().String()
+	dead.Close()
+
+	cc, err := NewClusterWithOptions([]string{deadAddr, good.addr()}, ClusterOptions{
+		Backoff: ConstantBackoff{Delay: time.Millisecond},
+	})
+	require.NoError(t, err)
+	defer cc.Close()
+
+	err = cc.Ping()
+	require.NoError(t, err)
+	assert.Equal(t, good.addr(), cc.Endpoints()[cc.lastGood])
+}
+
+func TestClusterContextCancellationStopsRetries(t *testing.T) {
+	dead, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	deadAddr := dead.Addr().String()
+	dead.Close()
+
+	cc, err := NewClusterWithOptions([]string{deadAddr, deadAddr}, ClusterOptions{
+		Backoff: ConstantBackoff{Delay: 50 * time.Millisecond},
+	})
+	require.NoError(t, err)
+	defer cc.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = cc.PingWithContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// TestClusterContextCancellationDuringBackoffStopsRetries covers canceling
+// mid-operation, while a retry is waiting out its backoff between two dead
+// endpoints, rather than the already-canceled fast path above.
+func TestClusterContextCancellationDuringBackoffStopsRetries(t *testing.T) {
+	dead, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	deadAddr := dead.Addr().String()
+	dead.Close()
+
+	cc, err := NewClusterWithOptions([]string{deadAddr, deadAddr, deadAddr}, ClusterOptions{
+		Backoff: ConstantBackoff{Delay: 50 * time.Millisecond},
+	})
+	require.NoError(t, err)
+	defer cc.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	err = cc.PingWithContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// TestClusterSetEndpointsDuringFailoverDoesNotPanic stresses endpointOrderLocked
+// being computed against one snapshot of cc.endpoints while SetEndpoints
+// concurrently shrinks and grows it; indexing the order against a
+// mismatched, shorter slice used to panic with index out of range.
+func TestClusterSetEndpointsDuringFailoverDoesNotPanic(t *testing.T) {
+	dead, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	deadAddr := dead.Addr().String()
+	dead.Close()
+
+	cc, err := NewClusterWithOptions([]string{deadAddr, deadAddr, deadAddr}, ClusterOptions{
+		Backoff: ConstantBackoff{Delay: time.Millisecond},
+	})
+	require.NoError(t, err)
+	defer cc.Close()
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				_ = cc.Ping()
+			}
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			cc.SetEndpoints([]string{deadAddr})
+			cc.SetEndpoints([]string{deadAddr, deadAddr, deadAddr})
+		}
+		close(stop)
+	}()
+
+	wg.Wait()
+}
+
+// TestClusterMarkGoodSurvivesConcurrentSetEndpointsShrink stresses markGood
+// storing an index a call observed in its own (possibly stale)
+// snapshotOrder snapshot while SetEndpoints concurrently shrinks the
+// endpoint list out from under it; a stale index stored verbatim used to
+// leave lastGood pointing past the end of the shrunk slice, panicking the
+// next call's endpoints[idx] lookup.
+func TestClusterMarkGoodSurvivesConcurrentSetEndpointsShrink(t *testing.T) {
+	good := newStubServer(t)
+	defer good.close()
+
+	dead, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	deadAddr := dead.Addr().String()
+	dead.Close()
+
+	cc, err := NewClusterWithOptions([]string{deadAddr, deadAddr, deadAddr, good.addr()}, ClusterOptions{
+		Backoff: ConstantBackoff{Delay: time.Millisecond},
+	})
+	require.NoError(t, err)
+	defer cc.Close()
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				_ = cc.Ping() // succeeds via good, driving markGood
+			}
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			cc.SetEndpoints([]string{deadAddr, good.addr()})
+			cc.SetEndpoints([]string{deadAddr, deadAddr, deadAddr, good.addr()})
+		}
+		close(stop)
+	}()
+
+	wg.Wait()
+
+	// lastGood must still be a valid index into the current endpoints.
+	require.NoError(t, cc.Ping())
+}
+
+func TestClusterRecoversWhenEndpointComesBack(t *testing.T) {
+	primary := newStubServer(t)
+	secondary := newStubServer(t)
+	defer secondary.close()
+
+	primaryAddr := primary.addr()
+	primary.close() // primary starts out down
+
+	cc, err := NewClusterWithOptions([]string{primaryAddr, secondary.addr()}, ClusterOptions{
+		Backoff: ConstantBackoff{Delay: time.Millisecond},
+	})
+	require.NoError(t, err)
+	defer cc.Close()
+
+	require.NoError(t, cc.Ping())
+	assert.Equal(t, secondary.addr(), cc.Endpoints()[cc.lastGood])
+
+	// Bring the primary back up on the same address. Its cached client never
+	// connected, so the next attempt against it dials afresh.
+	relistener, err := net.Listen("tcp", primaryAddr)
+	require.NoError(t, err)
+	revived := &stubServer{ln: relistener}
+	go revived.serve()
+	defer revived.close()
+
+	secondary.close() // secondary now goes down, forcing failover back to primary
+
+	require.Eventually(t, func() bool {
+		return cc.Ping() == nil
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, primaryAddr, cc.Endpoints()[cc.lastGood])
+}
+
+func TestClusterGetUsesFailover(t *testing.T) {
+	good := newStubServer(t)
+	defer good.close()
+
+	dead, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	deadAddr := dead.Addr().String()
+	dead.Close()
+
+	cc, err := NewClusterWithOptions([]string{deadAddr, good.addr()}, ClusterOptions{
+		Backoff: ConstantBackoff{Delay: time.Millisecond},
+	})
+	require.NoError(t, err)
+	defer cc.Close()
+
+	value, err := cc.Get("anykey")
+	require.NoError(t, err)
+	assert.Equal(t, "stub", value)
+}
+
+func TestNewClusterWithOptionsRequiresEndpoint(t *testing.T) {
+	_, err := NewClusterWithOptions(nil, ClusterOptions{})
+	assert.Error(t, err)
+}
+
+func TestClusterSetEndpoints(t *testing.T) {
+	cc, err := NewCluster([]string{"127.0.0.1:1"})
+	require.NoError(t, err)
+	defer cc.Close()
+
+	cc.SetEndpoints([]string{"127.0.0.1:2", "127.0.0.1:3"})
+	assert.Equal(t, []string{"127.0.0.1:2", "127.0.0.1:3"}, cc.Endpoints())
+}
+
+func TestExponentialBackoffCapsAtMax(t *testing.T) {
+	b := ExponentialBackoff{Base: 10 * time.Millisecond, Max: 40 * time.Millisecond, Jitter: 0}
+	assert.Equal(t, 40*time.Millisecond, b.Next(10))
+}
@@ -15,6 +15,20 @@ var (
 
 	// ErrNotFound is returned when a key is not found in the store.
 	ErrNotFound = errors.New("key not found")
+
+	// ErrTimeout is the sentinel matched by errors.Is against any *TimeoutError,
+	// regardless of which operation or deadline produced it.
+	ErrTimeout = errors.New("operation timed out")
+
+	// ErrConnection is the sentinel matched by errors.Is against any *ConnectionError.
+	ErrConnection = errors.New("connection error")
+
+	// ErrProtocol is the sentinel matched by errors.Is against any *ProtocolError.
+	ErrProtocol = errors.New("protocol error")
+
+	// ErrCircuitOpen is returned by CircuitBreakerInterceptor when a command
+	// is rejected without being sent because the breaker has tripped.
+	ErrCircuitOpen = errors.New("circuit breaker open")
 )
 
 // ConnectionError represents connection-related errors.
@@ -31,22 +45,53 @@ func (e *ConnectionError) Unwrap() error {
 	return e.Err
 }
 
+// Is reports whether target is ErrConnection, so callers can test for any
+// ConnectionError with errors.Is(err, merklekv.ErrConnection) instead of a
+// type assertion.
+func (e *ConnectionError) Is(target error) bool {
+	return target == ErrConnection
+}
+
 // TimeoutError represents timeout-related errors.
 type TimeoutError struct {
 	Op      string
 	Timeout string
+	Err     error
 }
 
 func (e *TimeoutError) Error() string {
 	return fmt.Sprintf("timeout during %s after %s", e.Op, e.Timeout)
 }
 
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrTimeout, so callers can test for any
+// TimeoutError with errors.Is(err, merklekv.ErrTimeout) instead of a type
+// assertion.
+func (e *TimeoutError) Is(target error) bool {
+	return target == ErrTimeout
+}
+
 // ProtocolError represents protocol-related errors from the server.
 type ProtocolError struct {
 	Op      string
 	Message string
+	Err     error
 }
 
 func (e *ProtocolError) Error() string {
 	return fmt.Sprintf("protocol error during %s: %s", e.Op, e.Message)
 }
+
+func (e *ProtocolError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrProtocol, so callers can test for any
+// ProtocolError with errors.Is(err, merklekv.ErrProtocol) instead of a type
+// assertion.
+func (e *ProtocolError) Is(target error) bool {
+	return target == ErrProtocol
+}
@@ -89,7 +89,7 @@ func TestIntegrationBasicOperations(t *testing.T) {
 
 	// Test GET after DELETE
 	_, err = client.Get("integration_test")
-	assert.Equal(t, ErrNotFound, err)
+	assert.ErrorIs(t, err, ErrNotFound)
 }
 
 func TestIntegrationPing(t *testing.T) {
@@ -208,7 +208,7 @@ func TestIntegrationGetNotFound(t *testing.T) {
 
 	// Get non-existent key should return ErrNotFound
 	_, err = client.Get("definitely_not_found_key_xyz")
-	assert.Equal(t, ErrNotFound, err)
+	assert.ErrorIs(t, err, ErrNotFound)
 }
 
 func TestIntegrationIsConnected(t *testing.T) {
@@ -0,0 +1,220 @@
+package merklekv
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Invoker sends a single raw command and returns the server's raw response,
+// matching sendCommand's signature.
+type Invoker func(ctx context.Context, command string) (string, error)
+
+// Interceptor wraps next with additional behavior (retries, circuit
+// breaking, metrics, tracing, ...) and returns the wrapped Invoker, the way
+// an outbound filter chain wraps a transport. Interceptors compose in the
+// order they appear in ClientOptions.Interceptors: the first interceptor is
+// outermost and sees every call first.
+type Interceptor func(next Invoker) Invoker
+
+// BatchInvoker is Invoker's Pipeline analogue.
+type BatchInvoker func(ctx context.Context, commands []string) ([]string, error)
+
+// BatchInterceptor is Interceptor's Pipeline analogue.
+type BatchInterceptor func(next BatchInvoker) BatchInvoker
+
+// chainInterceptors builds a single Invoker that runs interceptors[0], then
+// interceptors[1], ..., around base, so interceptors[0] is outermost.
+func chainInterceptors(interceptors []Interceptor, base Invoker) Invoker {
+	invoker := base
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		invoker = interceptors[i](invoker)
+	}
+	return invoker
+}
+
+// chainBatchInterceptors is chainInterceptors's Pipeline analogue.
+func chainBatchInterceptors(interceptors []BatchInterceptor, base BatchInvoker) BatchInvoker {
+	invoker := base
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		invoker = interceptors[i](invoker)
+	}
+	return invoker
+}
+
+// isRetryableErr reports whether err is a transient transport failure
+// (ConnectionError or TimeoutError) as opposed to a ProtocolError, which
+// reflects something the server will keep rejecting.
+func isRetryableErr(err error) bool {
+	return errors.Is(err, ErrConnection) || errors.Is(err, ErrTimeout)
+}
+
+// commandVerb extracts the leading verb of a command string (e.g. "GET" out
+// of "GET some-key"), used to label metrics without leaking key cardinality.
+func commandVerb(command string) string {
+	if i := strings.IndexByte(command, ' '); i >= 0 {
+		return command[:i]
+	}
+	return command
+}
+
+// RetryPolicy configures RetryInterceptor.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values <= 1 disable retrying. Defaults to 1.
+	MaxAttempts int
+	// Backoff is consulted between retry attempts. Defaults to
+	// ExponentialBackoff{Base: 50ms, Max: 2s, Jitter: 0.2}.
+	Backoff BackoffPolicy
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = 1
+	}
+	if p.Backoff == nil {
+		p.Backoff = ExponentialBackoff{Base: 50 * time.Millisecond, Max: 2 * time.Second, Jitter: 0.2}
+	}
+	return p
+}
+
+// RetryInterceptor retries a command up to policy.MaxAttempts times with
+// backoff between attempts, but only when it fails with a ConnectionError or
+// TimeoutError; a ProtocolError (the server rejecting the command itself) is
+// returned immediately since retrying it would just fail the same way. The
+// backoff honors ctx's deadline, returning ctx.Err() instead of waiting past it.
+//
+// Commands are only safe to retry blindly when they're idempotent (GET,
+// SET, DELETE, PING all are under the MerkleKV protocol); a caller composing
+// interceptors for a non-idempotent command should not include this one.
+func RetryInterceptor(policy RetryPolicy) Interceptor {
+	policy = policy.withDefaults()
+	return func(next Invoker) Invoker {
+		return func(ctx context.Context, command string) (string, error) {
+			var lastErr error
+			for attempt := 0; attempt < policy.MaxAttempts; attempt++ {
+				if attempt > 0 {
+					timer := time.NewTimer(policy.Backoff.Next(attempt - 1))
+					select {
+					case <-ctx.Done():
+						timer.Stop()
+						return "", ctx.Err()
+					case <-timer.C:
+					}
+				}
+
+				response, err := next(ctx, command)
+				if err == nil || !isRetryableErr(err) {
+					return response, err
+				}
+				lastErr = err
+			}
+			return "", lastErr
+		}
+	}
+}
+
+// circuitState is the state of a CircuitBreakerInterceptor.
+type circuitState int32
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// CircuitBreakerOptions configures CircuitBreakerInterceptor.
+type CircuitBreakerOptions struct {
+	// FailureThreshold trips the breaker after this many consecutive
+	// retryable failures. Defaults to 5.
+	FailureThreshold int
+	// OpenDuration is how long the breaker stays open before allowing a
+	// single half-open probe through. Defaults to 10s.
+	OpenDuration time.Duration
+}
+
+func (o CircuitBreakerOptions) withDefaults() CircuitBreakerOptions {
+	if o.FailureThreshold <= 0 {
+		o.FailureThreshold = 5
+	}
+	if o.OpenDuration <= 0 {
+		o.OpenDuration = 10 * time.Second
+	}
+	return o
+}
+
+// CircuitBreakerInterceptor rejects commands with ErrCircuitOpen once
+// consecutive retryable failures reach opts.FailureThreshold, instead of
+// letting every caller pile onto a server that's already down. After
+// opts.OpenDuration it lets exactly one probe command through (half-open);
+// that probe's outcome decides whether the breaker closes again or re-opens
+// for another OpenDuration.
+func CircuitBreakerInterceptor(opts CircuitBreakerOptions) Interceptor {
+	opts = opts.withDefaults()
+
+	var (
+		state        int32 // circuitState
+		failures     int32
+		probing      int32 // atomic bool guarding a single in-flight half-open probe
+		openedAtUnix int64 // UnixNano of when the breaker last tripped open
+	)
+
+	return func(next Invoker) Invoker {
+		return func(ctx context.Context, command string) (string, error) {
+			switch circuitState(atomic.LoadInt32(&state)) {
+			case circuitOpen:
+				if time.Since(time.Unix(0, atomic.LoadInt64(&openedAtUnix))) < opts.OpenDuration {
+					return "", ErrCircuitOpen
+				}
+				if !atomic.CompareAndSwapInt32(&probing, 0, 1) {
+					return "", ErrCircuitOpen // another probe already in flight
+				}
+				defer atomic.StoreInt32(&probing, 0)
+
+				response, err := next(ctx, command)
+				if err != nil && isRetryableErr(err) {
+					atomic.StoreInt64(&openedAtUnix, time.Now().UnixNano())
+					return response, err
+				}
+				atomic.StoreInt32(&state, int32(circuitClosed))
+				atomic.StoreInt32(&failures, 0)
+				return response, err
+
+			default:
+				response, err := next(ctx, command)
+				if err != nil && isRetryableErr(err) {
+					if atomic.AddInt32(&failures, 1) >= int32(opts.FailureThreshold) {
+						atomic.StoreInt32(&state, int32(circuitOpen))
+						atomic.StoreInt64(&openedAtUnix, time.Now().UnixNano())
+					}
+				} else {
+					atomic.StoreInt32(&failures, 0)
+				}
+				return response, err
+			}
+		}
+	}
+}
+
+// Recorder receives the outcome of every command MetricsInterceptor
+// observes. Implementations decide where those numbers go; see the
+// merklekvprom subpackage for a ready-made Prometheus adapter.
+type Recorder interface {
+	// ObserveCommand is called once per command with its verb (e.g. "GET"),
+	// latency, and error (nil on success).
+	ObserveCommand(verb string, duration time.Duration, err error)
+}
+
+// MetricsInterceptor records every command's verb, latency, and outcome via rec.
+func MetricsInterceptor(rec Recorder) Interceptor {
+	return func(next Invoker) Invoker {
+		return func(ctx context.Context, command string) (string, error) {
+			start := time.Now()
+			response, err := next(ctx, command)
+			rec.ObserveCommand(commandVerb(command), time.Since(start), err)
+			return response, err
+		}
+	}
+}
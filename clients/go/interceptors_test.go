@@ -0,0 +1,248 @@
+package merklekv
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyStubServer answers the line protocol normally, except it silently
+// closes the connection (instead of responding) for the first failCommands
+// commands it sees across any connection, simulating induced write/read
+// failures on an otherwise healthy server.
+type flakyStubServer struct {
+	ln   net.Listener
+	mu   sync.Mutex
+	fail int
+}
+
+func newFlakyStubServer(t *testing.T, failCommands int) *flakyStubServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &flakyStubServer{ln: ln, fail: failCommands}
+	go s.serve()
+	return s
+}
+
+func (s *flakyStubServer) addr() string { return s.ln.Addr().String() }
+func (s *flakyStubServer) close()       { s.ln.Close() }
+
+func (s *flakyStubServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *flakyStubServer) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.TrimSpace(line)
+
+		s.mu.Lock()
+		shouldFail := s.fail > 0
+		if shouldFail {
+			s.fail--
+		}
+		s.mu.Unlock()
+
+		if shouldFail {
+			return // drop the connection without responding
+		}
+
+		switch {
+		case strings.HasPrefix(cmd, "GET "):
+			conn.Write([]byte("VALUE stub\r\n"))
+		default:
+			conn.Write([]byte("OK\r\n"))
+		}
+	}
+}
+
+// retryBatchInterceptor is RetryInterceptor's BatchInvoker analogue. It only
+// exists here to prove ClientOptions.BatchInterceptors is actually wired
+// through Pipeline the same way ClientOptions.Interceptors is through
+// sendCommand; production code has no built-in batch retry interceptor yet.
+func retryBatchInterceptor(policy RetryPolicy) BatchInterceptor {
+	policy = policy.withDefaults()
+	return func(next BatchInvoker) BatchInvoker {
+		return func(ctx context.Context, commands []string) ([]string, error) {
+			var lastErr error
+			for attempt := 0; attempt < policy.MaxAttempts; attempt++ {
+				if attempt > 0 {
+					timer := time.NewTimer(policy.Backoff.Next(attempt - 1))
+					select {
+					case <-ctx.Done():
+						timer.Stop()
+						return nil, ctx.Err()
+					case <-timer.C:
+					}
+				}
+
+				responses, err := next(ctx, commands)
+				if err == nil || !isRetryableErr(err) {
+					return responses, err
+				}
+				lastErr = err
+			}
+			return nil, lastErr
+		}
+	}
+}
+
+func TestBatchInterceptorRetriesPipelinePastInducedFailures(t *testing.T) {
+	server := newFlakyStubServer(t, 2)
+	defer server.close()
+
+	host, port := splitAddr(t, server.addr())
+	client := NewWithOptions(host, port, ClientOptions{
+		MaxReconnects:  3,
+		ReconnectDelay: time.Millisecond,
+		BatchInterceptors: []BatchInterceptor{
+			retryBatchInterceptor(RetryPolicy{MaxAttempts: 3, Backoff: ConstantBackoff{Delay: time.Millisecond}}),
+		},
+	})
+	defer client.Close()
+
+	require.NoError(t, client.Connect())
+
+	responses, err := client.Pipeline([]string{"SET k v", "GET k"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"OK", "VALUE stub"}, responses)
+}
+
+func TestRetryInterceptorRetriesPastInducedFailures(t *testing.T) {
+	server := newFlakyStubServer(t, 2)
+	defer server.close()
+
+	host, port := splitAddr(t, server.addr())
+	client := NewWithOptions(host, port, ClientOptions{
+		MaxReconnects:  3,
+		ReconnectDelay: time.Millisecond,
+		Interceptors: []Interceptor{
+			RetryInterceptor(RetryPolicy{MaxAttempts: 3, Backoff: ConstantBackoff{Delay: time.Millisecond}}),
+		},
+	})
+	defer client.Close()
+
+	require.NoError(t, client.Connect())
+
+	value, err := client.Get("key")
+	require.NoError(t, err)
+	assert.Equal(t, "stub", value)
+}
+
+func TestRetryInterceptorGivesUpAfterMaxAttempts(t *testing.T) {
+	server := newFlakyStubServer(t, 5)
+	defer server.close()
+
+	host, port := splitAddr(t, server.addr())
+	client := NewWithOptions(host, port, ClientOptions{
+		MaxReconnects:  3,
+		ReconnectDelay: time.Millisecond,
+		Interceptors: []Interceptor{
+			RetryInterceptor(RetryPolicy{MaxAttempts: 2, Backoff: ConstantBackoff{Delay: time.Millisecond}}),
+		},
+	})
+	defer client.Close()
+
+	require.NoError(t, client.Connect())
+
+	_, err := client.Get("key")
+	assert.Error(t, err)
+}
+
+func TestRetryInterceptorDoesNotRetryProtocolError(t *testing.T) {
+	calls := 0
+	invoker := RetryInterceptor(RetryPolicy{MaxAttempts: 5, Backoff: ConstantBackoff{Delay: time.Millisecond}})(
+		func(ctx context.Context, command string) (string, error) {
+			calls++
+			return "", &ProtocolError{Op: "get", Message: "boom"}
+		},
+	)
+
+	_, err := invoker(context.Background(), "GET key")
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls, "a ProtocolError should never be retried")
+}
+
+func TestCircuitBreakerOpensThenRecoversOnHalfOpenProbe(t *testing.T) {
+	var calls int
+	breaker := CircuitBreakerInterceptor(CircuitBreakerOptions{FailureThreshold: 2, OpenDuration: 20 * time.Millisecond})
+
+	failing := true
+	invoker := breaker(func(ctx context.Context, command string) (string, error) {
+		calls++
+		if failing {
+			return "", &ConnectionError{Op: "write", Err: assert.AnError}
+		}
+		return "OK", nil
+	})
+
+	ctx := context.Background()
+	_, err := invoker(ctx, "PING")
+	assert.Error(t, err)
+	_, err = invoker(ctx, "PING")
+	assert.Error(t, err) // trips the breaker (threshold 2)
+
+	_, err = invoker(ctx, "PING")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 2, calls, "breaker should short-circuit without calling next")
+
+	time.Sleep(30 * time.Millisecond)
+	failing = false
+
+	resp, err := invoker(ctx, "PING") // half-open probe succeeds
+	require.NoError(t, err)
+	assert.Equal(t, "OK", resp)
+
+	resp, err = invoker(ctx, "PING") // breaker closed again
+	require.NoError(t, err)
+	assert.Equal(t, "OK", resp)
+}
+
+type fakeRecorder struct {
+	mu      sync.Mutex
+	verbs   []string
+	outcome []bool
+}
+
+func (r *fakeRecorder) ObserveCommand(verb string, duration time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.verbs = append(r.verbs, verb)
+	r.outcome = append(r.outcome, err == nil)
+}
+
+func TestMetricsInterceptorRecordsVerbAndOutcome(t *testing.T) {
+	rec := &fakeRecorder{}
+	invoker := MetricsInterceptor(rec)(func(ctx context.Context, command string) (string, error) {
+		if command == "GET missing" {
+			return "", ErrNotFound
+		}
+		return "OK", nil
+	})
+
+	_, _ = invoker(context.Background(), "SET key value")
+	_, _ = invoker(context.Background(), "GET missing")
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	assert.Equal(t, []string{"SET", "GET"}, rec.verbs)
+	assert.Equal(t, []bool{true, false}, rec.outcome)
+}
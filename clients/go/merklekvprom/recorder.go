@@ -0,0 +1,47 @@
+// Package merklekvprom provides a Prometheus-backed merklekv.Recorder for use
+// with merklekv.MetricsInterceptor. It lives in its own module-internal
+// package so importing the main merklekv client never pulls in
+// client_golang as a hard dependency.
+package merklekvprom
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Recorder implements merklekv.Recorder on top of Prometheus counter and
+// histogram vectors keyed by command verb (GET, SET, DELETE, PING) and
+// outcome ("ok" or "error").
+type Recorder struct {
+	commandsTotal   *prometheus.CounterVec
+	commandDuration *prometheus.HistogramVec
+}
+
+// NewRecorder creates a Recorder and registers its metrics with reg. Pass
+// prometheus.DefaultRegisterer to use the global registry.
+func NewRecorder(reg prometheus.Registerer) *Recorder {
+	r := &Recorder{
+		commandsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "merklekv_client_commands_total",
+			Help: "Total MerkleKV client commands, by verb and outcome.",
+		}, []string{"verb", "outcome"}),
+		commandDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "merklekv_client_command_duration_seconds",
+			Help:    "MerkleKV client command latency in seconds, by verb.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"verb"}),
+	}
+	reg.MustRegister(r.commandsTotal, r.commandDuration)
+	return r
+}
+
+// ObserveCommand implements merklekv.Recorder.
+func (r *Recorder) ObserveCommand(verb string, duration time.Duration, err error) {
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	r.commandsTotal.WithLabelValues(verb, outcome).Inc()
+	r.commandDuration.WithLabelValues(verb).Observe(duration.Seconds())
+}
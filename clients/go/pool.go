@@ -0,0 +1,349 @@
+package merklekv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Role tags a pool endpoint as accepting writes (RW) or reads only (RO).
+type Role int
+
+const (
+	// RW endpoints accept both reads and writes.
+	RW Role = iota
+	// RO endpoints accept reads only; Pool never routes Set/Delete to them.
+	RO
+)
+
+func (r Role) String() string {
+	if r == RO {
+		return "RO"
+	}
+	return "RW"
+}
+
+// Endpoint is one "host:port" server tagged with the role Pool should use it for.
+type Endpoint struct {
+	Address string
+	Role    Role
+}
+
+// PoolStrategy selects how Pool picks among the healthy candidates for a call.
+type PoolStrategy int
+
+const (
+	// PoolRoundRobin rotates through healthy candidates on every call.
+	PoolRoundRobin PoolStrategy = iota
+	// PoolLeastInFlight prefers the healthy candidate with fewest in-flight requests.
+	PoolLeastInFlight
+)
+
+// DialFunc dials a single pool member's connection, letting callers plug in
+// TLS or other custom transports.
+type DialFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// dialerFunc adapts a DialFunc to the Dialer interface so it can be plugged
+// into a member Client's ClientOptions.Dialer.
+type dialerFunc DialFunc
+
+func (f dialerFunc) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	return f(ctx, network, address)
+}
+
+// PoolOptions configures a Pool.
+type PoolOptions struct {
+	// Strategy selects candidates among healthy endpoints. Defaults to PoolRoundRobin.
+	Strategy PoolStrategy
+	// ProbeInterval is how often each endpoint is health-checked. Defaults to 5s.
+	ProbeInterval time.Duration
+	// ConnectTimeout bounds connection establishment and is used as each
+	// member Client's operation timeout. Defaults to 5s.
+	ConnectTimeout time.Duration
+	// MaxInFlight caps concurrent requests per endpoint; zero means unbounded.
+	MaxInFlight int
+	// Dialer, if set, is used instead of a plain net.Dialer to establish
+	// each member's connection (e.g. to plug in TLS).
+	Dialer DialFunc
+}
+
+// poolMember tracks one endpoint's Client along with the health and
+// in-flight bookkeeping Pool needs to select and re-admit it.
+type poolMember struct {
+	endpoint Endpoint
+	client   *Client
+
+	healthy  int32 // atomic bool (0/1)
+	inFlight int32
+}
+
+func (m *poolMember) isHealthy() bool       { return atomic.LoadInt32(&m.healthy) == 1 }
+func (m *poolMember) setHealthy(ok bool)    { atomic.StoreInt32(&m.healthy, boolToInt32(ok)) }
+func (m *poolMember) addInFlight(delta int32) int32 {
+	return atomic.AddInt32(&m.inFlight, delta)
+}
+
+func boolToInt32(ok bool) int32 {
+	if ok {
+		return 1
+	}
+	return 0
+}
+
+// Pool manages a set of Client instances across one or more MerkleKV
+// servers, selecting among role-tagged, health-checked endpoints on every
+// call and failing over transparently when one becomes unreachable.
+type Pool struct {
+	mu      sync.RWMutex
+	members []*poolMember
+	opts    PoolOptions
+	cursor  uint64
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewPool creates a Pool over the given endpoints, starts its background
+// health prober, and arranges for the pool (and every underlying Client) to
+// be shut down when ctx is canceled.
+func NewPool(ctx context.Context, endpoints []Endpoint, opts PoolOptions) (*Pool, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("merklekv: pool requires at least one endpoint")
+	}
+	if opts.ProbeInterval <= 0 {
+		opts.ProbeInterval = 5 * time.Second
+	}
+	if opts.ConnectTimeout <= 0 {
+		opts.ConnectTimeout = 5 * time.Second
+	}
+
+	p := &Pool{
+		opts: opts,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	for _, ep := range endpoints {
+		host, port, err := splitHostPort(ep.Address)
+		if err != nil {
+			return nil, err
+		}
+		p.members = append(p.members, &poolMember{
+			endpoint: ep,
+			client:   p.newMemberClient(host, port),
+			healthy:  1, // optimistically healthy until the first probe says otherwise
+		})
+	}
+
+	go p.probeLoop()
+	context.AfterFunc(ctx, func() { p.Close() })
+
+	return p, nil
+}
+
+// newMemberClient builds the Client for one endpoint, honoring
+// PoolOptions.Dialer when set so a TLS (or other custom) transport applies
+// to every member, not just the default plain TCP dial.
+func (p *Pool) newMemberClient(host string, port int) *Client {
+	clientOpts := ClientOptions{}.withDefaults()
+	if p.opts.Dialer != nil {
+		clientOpts.Dialer = dialerFunc(p.opts.Dialer)
+	}
+	return &Client{
+		host:    host,
+		port:    port,
+		timeout: p.opts.ConnectTimeout,
+		opts:    clientOpts,
+	}
+}
+
+func (p *Pool) probeLoop() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.opts.ProbeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.probeAll()
+		}
+	}
+}
+
+func (p *Pool) probeAll() {
+	p.mu.RLock()
+	members := append([]*poolMember(nil), p.members...)
+	p.mu.RUnlock()
+
+	for _, m := range members {
+		ctx, cancel := context.WithTimeout(context.Background(), p.opts.ConnectTimeout)
+		err := m.client.ConnectWithContext(ctx)
+		if err == nil {
+			_, err = m.client.HealthCheckWithContext(ctx)
+		}
+		cancel()
+		m.setHealthy(err == nil)
+	}
+}
+
+// Close stops the health prober and closes every underlying Client.
+func (p *Pool) Close() error {
+	select {
+	case <-p.stop:
+		// already closed
+	default:
+		close(p.stop)
+	}
+	<-p.done
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var firstErr error
+	for _, m := range p.members {
+		if err := m.client.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// candidates returns the healthy members eligible for role, ordered per the
+// configured PoolStrategy. Read calls (RO) also accept RW members as a
+// fallback; write calls (RW) never use RO-tagged members.
+func (p *Pool) candidates(role Role) []*poolMember {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var primary, fallback []*poolMember
+	for _, m := range p.members {
+		if !m.isHealthy() {
+			continue
+		}
+		switch {
+		case m.endpoint.Role == role:
+			primary = append(primary, m)
+		case role == RO && m.endpoint.Role == RW:
+			fallback = append(fallback, m)
+		}
+	}
+
+	switch p.opts.Strategy {
+	case PoolLeastInFlight:
+		sort.SliceStable(primary, func(i, j int) bool { return primary[i].inFlight < primary[j].inFlight })
+		sort.SliceStable(fallback, func(i, j int) bool { return fallback[i].inFlight < fallback[j].inFlight })
+	default: // PoolRoundRobin
+		n := atomic.AddUint64(&p.cursor, 1)
+		primary = rotate(primary, n)
+		fallback = rotate(fallback, n)
+	}
+
+	return append(primary, fallback...)
+}
+
+func rotate(members []*poolMember, n uint64) []*poolMember {
+	if len(members) == 0 {
+		return members
+	}
+	offset := int(n % uint64(len(members)))
+	return append(append([]*poolMember(nil), members[offset:]...), members[:offset]...)
+}
+
+// do runs fn against healthy candidates for role in order, marking an
+// endpoint unhealthy and trying the next one on a transient error.
+func (p *Pool) do(ctx context.Context, role Role, fn func(*Client) error) error {
+	candidates := p.candidates(role)
+	if len(candidates) == 0 {
+		return fmt.Errorf("merklekv: no healthy %s endpoints available", role)
+	}
+
+	var lastErr error
+	attempted := false
+	for _, m := range candidates {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if p.opts.MaxInFlight > 0 && int(atomic.LoadInt32(&m.inFlight)) >= p.opts.MaxInFlight {
+			continue
+		}
+		attempted = true
+
+		m.addInFlight(1)
+		err := p.callMember(ctx, m, fn)
+		m.addInFlight(-1)
+
+		if err == nil {
+			m.setHealthy(true)
+			return nil
+		}
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return err
+		}
+		if !isTransient(err) {
+			return err
+		}
+		m.setHealthy(false)
+		lastErr = err
+	}
+	if !attempted {
+		return fmt.Errorf("merklekv: all healthy %s endpoints at MaxInFlight", role)
+	}
+	return lastErr
+}
+
+func (p *Pool) callMember(ctx context.Context, m *poolMember, fn func(*Client) error) error {
+	if err := m.client.ConnectWithContext(ctx); err != nil {
+		return err
+	}
+	return fn(m.client)
+}
+
+// Get retrieves the value for a key from a healthy endpoint.
+func (p *Pool) Get(ctx context.Context, key string) (string, error) {
+	var value string
+	err := p.do(ctx, RO, func(c *Client) error {
+		v, err := c.GetWithContext(ctx, key)
+		if err != nil {
+			return err
+		}
+		value = v
+		return nil
+	})
+	return value, err
+}
+
+// Set stores a key-value pair on a healthy RW endpoint.
+func (p *Pool) Set(ctx context.Context, key, value string) error {
+	return p.do(ctx, RW, func(c *Client) error {
+		return c.SetWithContext(ctx, key, value)
+	})
+}
+
+// Delete removes a key on a healthy RW endpoint.
+func (p *Pool) Delete(ctx context.Context, key string) error {
+	return p.do(ctx, RW, func(c *Client) error {
+		return c.DeleteWithContext(ctx, key)
+	})
+}
+
+// Pipeline executes multiple commands against a single healthy RW endpoint.
+func (p *Pool) Pipeline(ctx context.Context, commands []string) ([]string, error) {
+	var responses []string
+	err := p.do(ctx, RW, func(c *Client) error {
+		r, err := c.PipelineWithContext(ctx, commands)
+		if err != nil {
+			return err
+		}
+		responses = r
+		return nil
+	})
+	return responses, err
+}
@@ -0,0 +1,183 @@
+package merklekv
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolRoutesWritesToRWOnly(t *testing.T) {
+	rw := newStubServer(t)
+	defer rw.close()
+	ro := newStubServer(t)
+	defer ro.close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pool, err := NewPool(ctx, []Endpoint{
+		{Address: rw.addr(), Role: RW},
+		{Address: ro.addr(), Role: RO},
+	}, PoolOptions{ProbeInterval: time.Hour})
+	require.NoError(t, err)
+	defer pool.Close()
+
+	require.NoError(t, pool.Set(context.Background(), "k", "v"))
+
+	// With the only RW member out of rotation, writes must not fall back to RO.
+	pool.members[0].setHealthy(false)
+	assert.Error(t, pool.Set(context.Background(), "k", "v"))
+}
+
+func TestPoolFailsOverToNextHealthyMember(t *testing.T) {
+	good := newStubServer(t)
+	defer good.close()
+
+	dead, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	deadAddr := dead.Addr().String()
+	dead.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pool, err := NewPool(ctx, []Endpoint{
+		{Address: deadAddr, Role: RW},
+		{Address: good.addr(), Role: RW},
+	}, PoolOptions{ProbeInterval: time.Hour})
+	require.NoError(t, err)
+	defer pool.Close()
+
+	value, err := pool.Get(context.Background(), "anykey")
+	require.NoError(t, err)
+	assert.Equal(t, "stub", value)
+}
+
+func TestPoolReProbesAndReadmitsEndpoint(t *testing.T) {
+	server := newStubServer(t)
+	addr := server.addr()
+	server.close() // starts down
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pool, err := NewPool(ctx, []Endpoint{{Address: addr, Role: RW}}, PoolOptions{
+		ProbeInterval:  20 * time.Millisecond,
+		ConnectTimeout: 200 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer pool.Close()
+
+	require.Eventually(t, func() bool {
+		return !pool.members[0].isHealthy()
+	}, time.Second, 10*time.Millisecond)
+
+	ln, err := net.Listen("tcp", addr)
+	require.NoError(t, err)
+	revived := &stubServer{ln: ln}
+	go revived.serve()
+	defer revived.close()
+
+	require.Eventually(t, func() bool {
+		return pool.members[0].isHealthy()
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestPoolNoHealthyEndpoints(t *testing.T) {
+	dead, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	deadAddr := dead.Addr().String()
+	dead.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pool, err := NewPool(ctx, []Endpoint{{Address: deadAddr, Role: RO}}, PoolOptions{ProbeInterval: time.Hour})
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.members[0].setHealthy(false)
+
+	_, err = pool.Get(context.Background(), "k")
+	assert.Error(t, err)
+}
+
+func TestPoolUsesConfiguredDialer(t *testing.T) {
+	cert := generateSelfSignedCert(t)
+	addr, rootCAs, closeFn := newTLSStubServer(t, cert)
+	defer closeFn()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pool, err := NewPool(ctx, []Endpoint{{Address: addr, Role: RW}}, PoolOptions{
+		ProbeInterval: time.Hour,
+		Dialer:        (&TLSDialer{Config: &tls.Config{RootCAs: rootCAs}}).DialContext,
+	})
+	require.NoError(t, err)
+	defer pool.Close()
+
+	require.NoError(t, pool.Set(context.Background(), "k", "v"))
+}
+
+func TestPoolWithoutDialerCannotReachTLSOnlyServer(t *testing.T) {
+	cert := generateSelfSignedCert(t)
+	addr, _, closeFn := newTLSStubServer(t, cert)
+	defer closeFn()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pool, err := NewPool(ctx, []Endpoint{{Address: addr, Role: RW}}, PoolOptions{ProbeInterval: time.Hour})
+	require.NoError(t, err)
+	defer pool.Close()
+
+	err = pool.Set(context.Background(), "k", "v")
+	assert.Error(t, err)
+}
+
+func TestPoolReturnsErrorWhenAllCandidatesAtMaxInFlight(t *testing.T) {
+	server := newStubServer(t)
+	defer server.close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pool, err := NewPool(ctx, []Endpoint{{Address: server.addr(), Role: RW}}, PoolOptions{
+		ProbeInterval: time.Hour,
+		MaxInFlight:   1,
+	})
+	require.NoError(t, err)
+	defer pool.Close()
+
+	pool.members[0].addInFlight(1) // pin the only member at MaxInFlight
+
+	_, err = pool.Get(context.Background(), "k")
+	assert.Error(t, err)
+}
+
+func TestPoolClosedOnContextCancel(t *testing.T) {
+	server := newStubServer(t)
+	defer server.close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	pool, err := NewPool(ctx, []Endpoint{{Address: server.addr(), Role: RW}}, PoolOptions{ProbeInterval: time.Hour})
+	require.NoError(t, err)
+
+	cancel()
+
+	require.Eventually(t, func() bool {
+		select {
+		case <-pool.done:
+			return true
+		default:
+			return false
+		}
+	}, time.Second, 10*time.Millisecond)
+}
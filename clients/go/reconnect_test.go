@@ -0,0 +1,138 @@
+package merklekv
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flappingListener accepts a connection and immediately closes it for the
+// first failClosures accepts, then behaves like a normal stub server.
+type flappingListener struct {
+	ln           net.Listener
+	failClosures int32
+}
+
+func newFlappingListener(t *testing.T, failClosures int32) *flappingListener {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	f := &flappingListener{ln: ln, failClosures: failClosures}
+	go f.serve()
+	return f
+}
+
+func (f *flappingListener) addr() string {
+	return f.ln.Addr().String()
+}
+
+func (f *flappingListener) close() {
+	f.ln.Close()
+}
+
+func (f *flappingListener) serve() {
+	for {
+		conn, err := f.ln.Accept()
+		if err != nil {
+			return
+		}
+		if f.failClosures > 0 {
+			f.failClosures--
+			conn.Close()
+			continue
+		}
+		go (&stubServer{}).handle(conn)
+	}
+}
+
+func TestConnectWithContextRetriesThroughFlappingListener(t *testing.T) {
+	flapper := newFlappingListener(t, 2)
+	defer flapper.close()
+
+	host, port := splitAddr(t, flapper.addr())
+	client := NewWithOptions(host, port, ClientOptions{
+		MaxReconnects:  5,
+		ReconnectDelay: time.Millisecond,
+	})
+	defer client.Close()
+
+	err := client.Connect()
+	require.NoError(t, err)
+	assert.True(t, client.IsConnected())
+}
+
+func TestConnectWithContextGivesUpAfterMaxReconnects(t *testing.T) {
+	dead, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	deadAddr := dead.Addr().String()
+	dead.Close()
+
+	host, port := splitAddr(t, deadAddr)
+	client := NewWithOptions(host, port, ClientOptions{
+		MaxReconnects:  2,
+		ReconnectDelay: time.Millisecond,
+	})
+
+	err = client.Connect()
+	require.Error(t, err)
+	var connErr *ConnectionError
+	require.True(t, errors.As(err, &connErr))
+}
+
+func TestConnectWithContextStopsOnCancellation(t *testing.T) {
+	dead, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	deadAddr := dead.Addr().String()
+	dead.Close()
+
+	host, port := splitAddr(t, deadAddr)
+	client := NewWithOptions(host, port, ClientOptions{
+		MaxReconnects:  100,
+		ReconnectDelay: 50 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	err = client.ConnectWithContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestSendCommandReconnectsAfterDrop(t *testing.T) {
+	server := newStubServer(t)
+	defer server.close()
+
+	host, port := splitAddr(t, server.addr())
+	client := NewWithOptions(host, port, ClientOptions{
+		MaxReconnects:  3,
+		ReconnectDelay: time.Millisecond,
+	})
+	defer client.Close()
+
+	require.NoError(t, client.Connect())
+	require.NoError(t, client.Ping())
+
+	// Simulate the underlying connection dropping mid-use, as sendCommand
+	// does internally on a write/read failure.
+	client.mu.Lock()
+	client.conn.Close()
+	client.connected = false
+	client.mu.Unlock()
+
+	// The next call should transparently reconnect rather than failing with
+	// ErrNotConnected, since the client was connected before.
+	require.NoError(t, client.Ping())
+}
+
+func TestSendCommandStillFailsFastWhenNeverConnected(t *testing.T) {
+	client := New("127.0.0.1", 1) // never Connect()'d
+	_, err := client.Get("key")
+	assert.ErrorIs(t, err, ErrNotConnected)
+}
@@ -0,0 +1,147 @@
+package merklekv
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Worker is a long-lived background task owned by a Service. It must return
+// once ctx is done; ctx is canceled when the owning Service is stopped.
+type Worker func(ctx context.Context) error
+
+// Service wraps a Client with a single well-defined start/stop lifecycle for
+// whatever long-lived background workers the client grows (a Watch reader, a
+// connection-pool health checker, a reconnect loop, a benchmark driver, ...),
+// modeled on Tendermint's libs/service BaseService. Instead of callers
+// juggling `defer client.Close()` alongside ad-hoc goroutines, Stop cancels
+// every worker, blocks until all of them have exited, closes the underlying
+// Client, and returns the first non-nil error any of them produced.
+type Service struct {
+	client  *Client
+	workers []Worker
+
+	mu      sync.Mutex
+	running bool
+	cancel  context.CancelFunc
+	done    chan struct{}
+	err     error
+}
+
+// NewService creates a Service around client that will run the given workers
+// for the lifetime of the service once Start is called.
+func NewService(client *Client, workers ...Worker) *Service {
+	return &Service{client: client, workers: workers}
+}
+
+// Start launches every registered worker. Calling Start on an already
+// running Service is a no-op and returns nil.
+func (s *Service) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return nil
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	s.running = true
+	s.err = nil
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, len(s.workers))
+	for _, w := range s.workers {
+		wg.Add(1)
+		go s.runWorker(runCtx, w, &wg, errCh)
+	}
+
+	done := s.done
+	go func() {
+		wg.Wait()
+		if len(s.workers) == 0 {
+			// With no workers to exit, the service runs until it is stopped.
+			<-runCtx.Done()
+		}
+		close(errCh)
+
+		var firstErr error
+		for err := range errCh {
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+
+		s.mu.Lock()
+		s.err = firstErr
+		s.running = false
+		s.mu.Unlock()
+		close(done)
+	}()
+
+	return nil
+}
+
+// runWorker runs a single worker, recovering any panic and surfacing it as
+// an error so one misbehaving worker cannot take down the process.
+func (s *Service) runWorker(ctx context.Context, w Worker, wg *sync.WaitGroup, errCh chan<- error) {
+	defer wg.Done()
+	defer func() {
+		if r := recover(); r != nil {
+			errCh <- fmt.Errorf("merklekv: worker panicked: %v", r)
+		}
+	}()
+
+	if err := w(ctx); err != nil {
+		errCh <- err
+	}
+}
+
+// Stop cancels every worker's context, blocks until they have all exited,
+// and closes the underlying Client. It is safe to call multiple times,
+// including on a Service that was never started.
+func (s *Service) Stop() error {
+	s.mu.Lock()
+	cancel := s.cancel
+	done := s.done
+	s.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+	if done != nil {
+		<-done
+	}
+
+	closeErr := s.client.Close()
+	if err := s.Wait(); err != nil {
+		return err
+	}
+	return closeErr
+}
+
+// Wait blocks until the service has fully stopped and returns the first
+// non-nil error produced by any worker, including a recovered panic. Wait
+// returns immediately with nil if Start was never called.
+func (s *Service) Wait() error {
+	s.mu.Lock()
+	done := s.done
+	s.mu.Unlock()
+
+	if done == nil {
+		return nil
+	}
+	<-done
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// IsRunning reports whether the service is currently started.
+func (s *Service) IsRunning() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running
+}
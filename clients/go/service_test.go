@@ -0,0 +1,139 @@
+package merklekv
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceStartStopIdempotent(t *testing.T) {
+	client := New("localhost", 7379)
+	svc := NewService(client)
+
+	require.NoError(t, svc.Start(context.Background()))
+	require.NoError(t, svc.Start(context.Background())) // second Start is a no-op
+	assert.True(t, svc.IsRunning())
+
+	require.NoError(t, svc.Stop())
+	require.NoError(t, svc.Stop()) // second Stop is a no-op
+	assert.False(t, svc.IsRunning())
+}
+
+func TestServiceStopWaitsForWorkers(t *testing.T) {
+	client := New("localhost", 7379)
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+
+	svc := NewService(client, func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		close(stopped)
+		return nil
+	})
+
+	require.NoError(t, svc.Start(context.Background()))
+	<-started
+
+	require.NoError(t, svc.Stop())
+
+	select {
+	case <-stopped:
+	default:
+		t.Fatal("Stop returned before worker exited")
+	}
+}
+
+func TestServiceStopClosesClient(t *testing.T) {
+	server := newStubServer(t)
+	defer server.close()
+
+	host, port := splitAddr(t, server.addr())
+	client := NewWithTimeout(host, port, time.Second)
+	require.NoError(t, client.Connect())
+
+	svc := NewService(client)
+	require.NoError(t, svc.Start(context.Background()))
+	require.True(t, client.IsConnected(), "client should be connected before Stop")
+
+	require.NoError(t, svc.Stop())
+
+	assert.False(t, client.IsConnected(), "Service.Stop should close the underlying Client")
+}
+
+// TestServiceStopDuringInFlightPipelineSurfacesErrNotConnected covers a
+// worker racing its Pipeline calls against a concurrent Stop. The client is
+// deliberately never Connect()'d, so every Pipeline call hits the
+// ErrNotConnected fast path without attempting any dial; that keeps the
+// in-flight call's outcome deterministic regardless of exactly when Stop's
+// cancel wins the race, rather than depending on network timing to interrupt
+// a call blocked on a real read.
+func TestServiceStopDuringInFlightPipelineSurfacesErrNotConnected(t *testing.T) {
+	client := New("127.0.0.1", 1)
+
+	started := make(chan struct{})
+	var startOnce sync.Once
+	lastErr := make(chan error, 1)
+
+	svc := NewService(client, func(ctx context.Context) error {
+		for {
+			_, err := client.Pipeline([]string{"PING"})
+			select {
+			case lastErr <- err:
+			default:
+				<-lastErr
+				lastErr <- err
+			}
+			startOnce.Do(func() { close(started) })
+
+			select {
+			case <-ctx.Done():
+				return err
+			default:
+			}
+		}
+	})
+
+	require.NoError(t, svc.Start(context.Background()))
+	<-started
+
+	stopErr := svc.Stop()
+
+	assert.ErrorIs(t, <-lastErr, ErrNotConnected, "in-flight Pipeline call should unblock with ErrNotConnected")
+	assert.ErrorIs(t, stopErr, ErrNotConnected, "Stop should propagate the worker's error cleanly")
+}
+
+func TestServiceWorkerPanicSurfacedThroughWait(t *testing.T) {
+	client := New("localhost", 7379)
+	svc := NewService(client, func(ctx context.Context) error {
+		panic("boom")
+	})
+
+	require.NoError(t, svc.Start(context.Background()))
+
+	err := svc.Wait()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boom")
+}
+
+func TestServiceWorkerErrorSurfacedThroughWait(t *testing.T) {
+	client := New("localhost", 7379)
+	sentinel := errors.New("worker failed")
+	svc := NewService(client, func(ctx context.Context) error {
+		return sentinel
+	})
+
+	require.NoError(t, svc.Start(context.Background()))
+	require.Eventually(t, func() bool { return !svc.IsRunning() }, time.Second, 10*time.Millisecond)
+
+	assert.ErrorIs(t, svc.Wait(), sentinel)
+}
+
+func TestServiceWaitWithoutStart(t *testing.T) {
+	svc := NewService(New("localhost", 7379))
+	assert.NoError(t, svc.Wait())
+}
@@ -0,0 +1,106 @@
+package merklekv
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"time"
+)
+
+// Dialer establishes the underlying connection for a Client, letting callers
+// plug in TLS or other custom transports in place of a plain TCP dial. It
+// must honor ctx's deadline for the dial (and, for TLSDialer, the handshake).
+type Dialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// TLSDialer dials a plain TCP connection, enables TCP_NODELAY, and then
+// performs a TLS handshake over it, honoring ctx's deadline for the
+// handshake itself. SNI defaults to the dialed host when Config.ServerName
+// is unset, matching how ConnectWithContext is normally called with a bare
+// host.
+type TLSDialer struct {
+	Config *tls.Config
+}
+
+// DialContext implements Dialer.
+func (d *TLSDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	rawConn, err := (&net.Dialer{}).DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	if tcpConn, ok := rawConn.(*net.TCPConn); ok {
+		if err := tcpConn.SetNoDelay(true); err != nil {
+			rawConn.Close()
+			return nil, err
+		}
+	}
+
+	cfg := d.Config
+	if cfg == nil {
+		cfg = &tls.Config{}
+	}
+	if cfg.ServerName == "" {
+		if host, _, err := net.SplitHostPort(address); err == nil {
+			cfg = cfg.Clone()
+			cfg.ServerName = host
+		}
+	}
+
+	tlsConn := tls.Client(rawConn, cfg)
+	if deadline, ok := ctx.Deadline(); ok {
+		tlsConn.SetDeadline(deadline)
+	}
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		rawConn.Close()
+		return nil, err
+	}
+
+	return tlsConn, nil
+}
+
+// NewWithTLS creates a MerkleKV client that dials over TLS using cfg,
+// supporting mutual TLS when cfg has client certificates configured, ALPN
+// via cfg.NextProtos, and custom RootCAs or InsecureSkipVerify as set by the
+// caller. SNI defaults to host when cfg.ServerName is empty.
+func NewWithTLS(host string, port int, cfg *tls.Config) *Client {
+	return &Client{
+		host:    host,
+		port:    port,
+		timeout: 5 * time.Second,
+		opts:    ClientOptions{Dialer: &TLSDialer{Config: cfg}}.withDefaults(),
+	}
+}
+
+// LoadClientTLSConfig builds a *tls.Config for mutual TLS from PEM-encoded
+// files on disk. certFile/keyFile may both be empty to skip client
+// authentication; caFile may be empty to use the system root CAs.
+func LoadClientTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	cfg := &tls.Config{}
+
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("merklekv: load client cert/key: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if caFile != "" {
+		pemBytes, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("merklekv: read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pemBytes) {
+			return nil, fmt.Errorf("merklekv: parse CA file %s: no certificates found", caFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	return cfg, nil
+}
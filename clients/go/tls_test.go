@@ -0,0 +1,123 @@
+package merklekv
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// generateSelfSignedCert creates an in-memory self-signed cert/key pair for
+// "127.0.0.1", used to stand up a TLS stub server without touching disk.
+func generateSelfSignedCert(t *testing.T) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		IsCA:         true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+		Leaf:        cert,
+	}
+}
+
+func newTLSStubServer(t *testing.T, serverCert tls.Certificate) (addr string, rootCAs *x509.CertPool, closeFn func()) {
+	pool := x509.NewCertPool()
+	pool.AddCert(serverCert.Leaf)
+
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+	})
+	require.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go (&stubServer{}).handle(conn)
+		}
+	}()
+
+	return ln.Addr().String(), pool, func() { ln.Close() }
+}
+
+func TestClientOverTLS(t *testing.T) {
+	serverCert := generateSelfSignedCert(t)
+	addr, rootCAs, closeFn := newTLSStubServer(t, serverCert)
+	defer closeFn()
+
+	host, port := splitAddr(t, addr)
+	client := NewWithTLS(host, port, &tls.Config{RootCAs: rootCAs})
+	defer client.Close()
+
+	require.NoError(t, client.Connect())
+	assert.True(t, client.IsConnected())
+
+	require.NoError(t, client.Ping())
+}
+
+func TestClientOverTLSRejectsUntrustedServer(t *testing.T) {
+	serverCert := generateSelfSignedCert(t)
+	addr, _, closeFn := newTLSStubServer(t, serverCert)
+	defer closeFn()
+
+	host, port := splitAddr(t, addr)
+	// No RootCAs configured, so the self-signed server cert is untrusted.
+	client := NewWithTLS(host, port, &tls.Config{})
+	defer client.Close()
+
+	err := client.Connect()
+	assert.Error(t, err)
+	assert.False(t, client.IsConnected())
+}
+
+func TestClientOverTLSInsecureSkipVerify(t *testing.T) {
+	serverCert := generateSelfSignedCert(t)
+	addr, _, closeFn := newTLSStubServer(t, serverCert)
+	defer closeFn()
+
+	host, port := splitAddr(t, addr)
+	client := NewWithTLS(host, port, &tls.Config{InsecureSkipVerify: true})
+	defer client.Close()
+
+	require.NoError(t, client.Connect())
+}
+
+func TestLoadClientTLSConfigNoCAFile(t *testing.T) {
+	cfg, err := LoadClientTLSConfig("", "", "")
+	require.NoError(t, err)
+	assert.Nil(t, cfg.Certificates)
+	assert.Nil(t, cfg.RootCAs)
+}
+
+func TestLoadClientTLSConfigMissingFile(t *testing.T) {
+	_, err := LoadClientTLSConfig("/no/such/cert.pem", "/no/such/key.pem", "")
+	assert.Error(t, err)
+}
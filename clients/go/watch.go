@@ -0,0 +1,341 @@
+package merklekv
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WatchEvent is a single key-change notification pushed by the server.
+type WatchEvent struct {
+	Op       string // "SET" or "DELETE"
+	Key      string
+	Value    string
+	Revision uint64
+}
+
+// WatchStats reports how many watch events have been dropped because a
+// subscriber's buffered channel was full.
+type WatchStats struct {
+	Dropped uint64
+}
+
+// watchSubscriberBuffer is the per-subscriber channel capacity. Events
+// beyond this are dropped rather than blocking the shared reader goroutine.
+const watchSubscriberBuffer = 100
+
+// watchSubscriber is one registered consumer of a watchBroadcaster.
+type watchSubscriber struct {
+	prefixes []string // empty means "match every key"
+	events   chan WatchEvent
+}
+
+func (s *watchSubscriber) matches(key string) bool {
+	if len(s.prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range s.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// watchDialFunc dials a fresh connection for the broadcaster to use, either
+// for the initial subscription or to re-establish one after a transport
+// failure.
+type watchDialFunc func(ctx context.Context) (net.Conn, error)
+
+// watchReconnectAttempts bounds how many times the broadcaster redials after
+// losing its connection before giving up and closing every subscriber.
+const watchReconnectAttempts = 5
+
+// watchBroadcaster owns the single dedicated connection used to receive
+// server push frames and fans them out to per-Watch-call subscriber
+// channels. It follows the pattern used by k8s-dqlite's broadcaster: one
+// background reader goroutine, a mutex-guarded subscriber map, and
+// drop-and-count instead of blocking on slow consumers. On a transport
+// failure it transparently redials and re-issues WATCH rather than tearing
+// down existing subscriptions.
+type watchBroadcaster struct {
+	mu     sync.Mutex
+	subs   map[int]*watchSubscriber
+	nextID int
+
+	dropped uint64
+
+	dial   watchDialFunc
+	conn   net.Conn
+	reader *bufio.Reader
+
+	// client is used only to clear Client.watchConn once run gives up for
+	// good, so the next Watch call redials fresh instead of subscribing to a
+	// broadcaster whose reader goroutine has already exited.
+	client *Client
+
+	stopping  chan struct{}
+	closeOnce sync.Once
+	closed    chan struct{}
+	runErr    error
+}
+
+func newWatchBroadcaster(client *Client, conn net.Conn, dial watchDialFunc) *watchBroadcaster {
+	b := &watchBroadcaster{
+		subs:     make(map[int]*watchSubscriber),
+		dial:     dial,
+		conn:     conn,
+		reader:   bufio.NewReader(conn),
+		client:   client,
+		stopping: make(chan struct{}),
+		closed:   make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// run reads push frames off the socket until the connection fails and
+// reconnecting is exhausted, or the broadcaster is closed, dispatching each
+// parsed event to every matching subscriber.
+func (b *watchBroadcaster) run() {
+	defer close(b.closed)
+
+	for {
+		line, err := b.reader.ReadString('\n')
+		if err != nil {
+			if b.reconnect() {
+				continue
+			}
+			b.mu.Lock()
+			b.runErr = err
+			for _, sub := range b.subs {
+				close(sub.events)
+			}
+			b.subs = map[int]*watchSubscriber{}
+			b.mu.Unlock()
+
+			select {
+			case <-b.stopping:
+				// Deliberately closed: Close already owns clearing
+				// Client.watchConn under watchMu, and is blocked waiting for
+				// b.closed, so clearing it here too would deadlock.
+			default:
+				b.client.clearWatchConn(b)
+			}
+			return
+		}
+
+		event, ok := parseWatchEvent(line)
+		if !ok {
+			continue
+		}
+		b.dispatch(event)
+	}
+}
+
+// reconnect redials and re-issues WATCH after the current connection fails,
+// retrying with exponential backoff. It returns false (giving up) as soon as
+// the broadcaster is explicitly closed or the dialer is exhausted.
+func (b *watchBroadcaster) reconnect() bool {
+	select {
+	case <-b.stopping:
+		return false
+	default:
+	}
+	b.conn.Close()
+
+	backoff := ExponentialBackoff{Base: 50 * time.Millisecond, Max: 2 * time.Second, Jitter: 0.2}
+	for attempt := 0; attempt < watchReconnectAttempts; attempt++ {
+		select {
+		case <-b.stopping:
+			return false
+		case <-time.After(backoff.Next(attempt)):
+		}
+
+		conn, err := b.dial(context.Background())
+		if err != nil {
+			continue
+		}
+		if _, err := conn.Write([]byte("WATCH *\r\n")); err != nil {
+			conn.Close()
+			continue
+		}
+
+		b.conn = conn
+		b.reader = bufio.NewReader(conn)
+		return true
+	}
+	return false
+}
+
+func (b *watchBroadcaster) dispatch(event WatchEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, sub := range b.subs {
+		if !sub.matches(event.Key) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			atomic.AddUint64(&b.dropped, 1)
+		}
+	}
+}
+
+// subscribe registers a new subscriber filtered to keyPrefixes (no prefixes
+// matches every key) and automatically unregisters it when ctx is done.
+func (b *watchBroadcaster) subscribe(ctx context.Context, keyPrefixes ...string) <-chan WatchEvent {
+	sub := &watchSubscriber{
+		prefixes: keyPrefixes,
+		events:   make(chan WatchEvent, watchSubscriberBuffer),
+	}
+
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.subs[id] = sub
+	b.mu.Unlock()
+
+	context.AfterFunc(ctx, func() {
+		b.mu.Lock()
+		if _, ok := b.subs[id]; ok {
+			delete(b.subs, id)
+			close(sub.events)
+		}
+		b.mu.Unlock()
+	})
+
+	return sub.events
+}
+
+func (b *watchBroadcaster) stats() WatchStats {
+	return WatchStats{Dropped: atomic.LoadUint64(&b.dropped)}
+}
+
+// close stops the broadcaster, draining every active subscriber by closing
+// its channel, and closes the dedicated connection.
+func (b *watchBroadcaster) close() error {
+	b.closeOnce.Do(func() {
+		close(b.stopping)
+		b.conn.Close()
+	})
+	<-b.closed
+
+	b.mu.Lock()
+	for id, sub := range b.subs {
+		close(sub.events)
+		delete(b.subs, id)
+	}
+	b.mu.Unlock()
+
+	return b.runErr
+}
+
+// parseWatchEvent parses a push frame of the form
+// "EVENT <SET|DELETE> <key> [value] <revision>".
+func parseWatchEvent(line string) (WatchEvent, bool) {
+	line = strings.TrimSpace(line)
+	parts := strings.SplitN(line, " ", 5)
+	if len(parts) < 4 || parts[0] != "EVENT" {
+		return WatchEvent{}, false
+	}
+
+	event := WatchEvent{Op: parts[1], Key: parts[2]}
+	revStr := parts[len(parts)-1]
+	if len(parts) == 5 {
+		event.Value = parts[3]
+	}
+	revision, err := strconv.ParseUint(revStr, 10, 64)
+	if err != nil {
+		return WatchEvent{}, false
+	}
+	event.Revision = revision
+
+	return event, true
+}
+
+// Watch streams change notifications for keys matching any of keyPrefixes
+// (no prefixes watches every key). Each prefix is matched with strings.
+// HasPrefix, so passing a full key subscribes to just that key.
+//
+// The first call opens a dedicated connection to the server and issues a
+// WATCH command; subsequent Watch calls share that connection and its single
+// parser goroutine, so normal request/reply operations and Pipeline on the
+// client's main connection are unaffected. The returned channel is closed
+// automatically once ctx is done, so callers never need to close it
+// themselves. Slow consumers have events dropped rather than blocking the
+// shared reader; see WatchStats. If the dedicated connection is lost, it is
+// transparently redialed and the subscription re-issued without callers
+// needing to call Watch again.
+func (c *Client) Watch(ctx context.Context, keyPrefixes ...string) (<-chan WatchEvent, error) {
+	b, err := c.ensureWatchBroadcaster(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return b.subscribe(ctx, keyPrefixes...), nil
+}
+
+// WatchStats reports how many watch events have been dropped across all
+// active Watch subscriptions because a subscriber's buffer was full.
+func (c *Client) WatchStats() WatchStats {
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+
+	if c.watchConn == nil {
+		return WatchStats{}
+	}
+	return c.watchConn.stats()
+}
+
+// dialWatchConn opens one connection for the watch broadcaster, honoring a
+// configured Dialer (e.g. TLSDialer) the same way the main connection does.
+func (c *Client) dialWatchConn(ctx context.Context) (net.Conn, error) {
+	address := fmt.Sprintf("%s:%d", c.host, c.port)
+	if c.opts.Dialer != nil {
+		return c.opts.Dialer.DialContext(ctx, "tcp", address)
+	}
+	dialer := &net.Dialer{Timeout: c.timeout}
+	return dialer.DialContext(ctx, "tcp", address)
+}
+
+func (c *Client) ensureWatchBroadcaster(ctx context.Context) (*watchBroadcaster, error) {
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+
+	if c.watchConn != nil {
+		return c.watchConn, nil
+	}
+
+	conn, err := c.dialWatchConn(ctx)
+	if err != nil {
+		return nil, &ConnectionError{Op: "watch connect", Err: err}
+	}
+
+	if _, err := conn.Write([]byte("WATCH *\r\n")); err != nil {
+		conn.Close()
+		return nil, &ConnectionError{Op: "watch subscribe", Err: err}
+	}
+
+	c.watchConn = newWatchBroadcaster(c, conn, c.dialWatchConn)
+	return c.watchConn, nil
+}
+
+// clearWatchConn removes b as the Client's active watch broadcaster if it
+// still is one. Called by a broadcaster's run goroutine once it gives up for
+// good, so a subsequent Watch call redials fresh instead of handing out a
+// subscription on a broadcaster nothing is dispatching to anymore.
+func (c *Client) clearWatchConn(b *watchBroadcaster) {
+	c.watchMu.Lock()
+	if c.watchConn == b {
+		c.watchConn = nil
+	}
+	c.watchMu.Unlock()
+}
@@ -0,0 +1,351 @@
+package merklekv
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// watchStubServer understands the normal GET/SET/PING request/reply commands
+// on every connection, and additionally treats "WATCH <prefix>" as turning
+// that connection into a one-way push stream driven by pushCh. pushCh is
+// guarded by mu so replacePushCh (simulating a transport failure) can swap
+// it out without racing the handle goroutine's read of the current channel.
+type watchStubServer struct {
+	ln net.Listener
+
+	mu     sync.Mutex
+	pushCh chan string
+	conns  []net.Conn
+}
+
+func newWatchStubServer(t *testing.T) *watchStubServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &watchStubServer{ln: ln, pushCh: make(chan string, 16)}
+	go s.serve()
+	return s
+}
+
+func (s *watchStubServer) addr() string {
+	return s.ln.Addr().String()
+}
+
+func (s *watchStubServer) close() {
+	s.ln.Close()
+}
+
+// closeAll stops accepting new connections and severs every connection
+// already accepted, so callers can simulate the server going away entirely
+// rather than just the watch stream hiccuping.
+func (s *watchStubServer) closeAll() {
+	s.ln.Close()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	s.conns = nil
+}
+
+// push sends msg on the current pushCh.
+func (s *watchStubServer) push(msg string) {
+	s.mu.Lock()
+	ch := s.pushCh
+	s.mu.Unlock()
+	ch <- msg
+}
+
+// currentPushCh returns the pushCh in effect right now, for a handler to
+// range over; replacePushCh closes this exact channel value before swapping
+// in a new one, so the range loop still terminates correctly.
+func (s *watchStubServer) currentPushCh() chan string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pushCh
+}
+
+// replacePushCh closes the current pushCh and installs a fresh one, used to
+// simulate the watch connection's transport failing out from under it.
+func (s *watchStubServer) replacePushCh() {
+	s.mu.Lock()
+	old := s.pushCh
+	s.pushCh = make(chan string, 16)
+	s.mu.Unlock()
+	close(old)
+}
+
+func (s *watchStubServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns = append(s.conns, conn)
+		s.mu.Unlock()
+		go s.handle(conn)
+	}
+}
+
+func (s *watchStubServer) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(cmd, "WATCH"):
+			for push := range s.currentPushCh() {
+				if _, err := conn.Write([]byte(push + "\r\n")); err != nil {
+					return
+				}
+			}
+			return
+		case cmd == "PING":
+			conn.Write([]byte("PONG\r\n"))
+		case strings.HasPrefix(cmd, "GET "):
+			conn.Write([]byte("VALUE stub\r\n"))
+		case strings.HasPrefix(cmd, "SET "):
+			conn.Write([]byte("OK\r\n"))
+		}
+	}
+}
+
+func TestWatchReceivesEvents(t *testing.T) {
+	server := newWatchStubServer(t)
+	defer server.close()
+
+	host, port := splitAddr(t, server.addr())
+	client := NewWithTimeout(host, port, time.Second)
+	require.NoError(t, client.Connect())
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.Watch(ctx, "user:")
+	require.NoError(t, err)
+
+	server.push("EVENT SET user:123 alice 1")
+	server.push("EVENT SET other:1 ignored 2")
+	server.push("EVENT DELETE user:123 7")
+
+	select {
+	case ev := <-events:
+		assert.Equal(t, WatchEvent{Op: "SET", Key: "user:123", Value: "alice", Revision: 1}, ev)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for watch event")
+	}
+
+	select {
+	case ev := <-events:
+		assert.Equal(t, WatchEvent{Op: "DELETE", Key: "user:123", Value: "", Revision: 7}, ev)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for watch event")
+	}
+}
+
+func TestWatchCoexistsWithPipeline(t *testing.T) {
+	server := newWatchStubServer(t)
+	defer server.close()
+
+	host, port := splitAddr(t, server.addr())
+	client := NewWithTimeout(host, port, time.Second)
+	require.NoError(t, client.Connect())
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err := client.Watch(ctx, "")
+	require.NoError(t, err)
+
+	// The main connection must still serve normal request/reply traffic.
+	responses, err := client.Pipeline([]string{"SET k v", "GET k"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"OK", "VALUE stub"}, responses)
+}
+
+func TestWatchUnsubscribesOnContextCancel(t *testing.T) {
+	server := newWatchStubServer(t)
+	defer server.close()
+
+	host, port := splitAddr(t, server.addr())
+	client := NewWithTimeout(host, port, time.Second)
+	require.NoError(t, client.Connect())
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	events, err := client.Watch(ctx, "")
+	require.NoError(t, err)
+
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok, "channel should be closed after context cancellation")
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for channel close")
+	}
+}
+
+func TestWatchDropsOnSlowConsumer(t *testing.T) {
+	server := newWatchStubServer(t)
+	defer server.close()
+
+	host, port := splitAddr(t, server.addr())
+	client := NewWithTimeout(host, port, time.Second)
+	require.NoError(t, client.Connect())
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err := client.Watch(ctx, "")
+	require.NoError(t, err)
+
+	for i := 0; i < watchSubscriberBuffer+10; i++ {
+		server.push("EVENT SET k v 1")
+	}
+
+	require.Eventually(t, func() bool {
+		return client.WatchStats().Dropped > 0
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestWatchMatchesAnyOfMultipleKeys(t *testing.T) {
+	server := newWatchStubServer(t)
+	defer server.close()
+
+	host, port := splitAddr(t, server.addr())
+	client := NewWithTimeout(host, port, time.Second)
+	require.NoError(t, client.Connect())
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.Watch(ctx, "user:", "order:")
+	require.NoError(t, err)
+
+	server.push("EVENT SET order:9 shipped 1")
+	server.push("EVENT SET other:1 ignored 2")
+	server.push("EVENT SET user:1 bob 3")
+
+	select {
+	case ev := <-events:
+		assert.Equal(t, WatchEvent{Op: "SET", Key: "order:9", Value: "shipped", Revision: 1}, ev)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for watch event")
+	}
+
+	select {
+	case ev := <-events:
+		assert.Equal(t, WatchEvent{Op: "SET", Key: "user:1", Value: "bob", Revision: 3}, ev)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for watch event")
+	}
+}
+
+func TestWatchReconnectsAfterTransportFailure(t *testing.T) {
+	server := newWatchStubServer(t)
+	defer server.close()
+
+	host, port := splitAddr(t, server.addr())
+	client := NewWithTimeout(host, port, time.Second)
+	require.NoError(t, client.Connect())
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.Watch(ctx, "")
+	require.NoError(t, err)
+
+	server.push("EVENT SET k1 v1 1")
+	select {
+	case ev := <-events:
+		assert.Equal(t, WatchEvent{Op: "SET", Key: "k1", Value: "v1", Revision: 1}, ev)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for watch event")
+	}
+
+	// Drop the dedicated watch connection from under the broadcaster to
+	// simulate a transport failure; it should redial and re-subscribe
+	// without the caller needing to call Watch again.
+	server.replacePushCh()
+
+	require.Eventually(t, func() bool {
+		server.push("EVENT SET k2 v2 2")
+		select {
+		case ev := <-events:
+			return assert.ObjectsAreEqual(WatchEvent{Op: "SET", Key: "k2", Value: "v2", Revision: 2}, ev)
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+// TestWatchRedialsFreshAfterReconnectAttemptsExhausted covers the case where
+// the watch connection is lost for good rather than just hiccuping: once
+// every reconnect attempt fails, the broadcaster's run goroutine must clear
+// Client.watchConn so the next Watch call redials instead of silently
+// handing back a subscription on a broadcaster nothing is dispatching to
+// anymore.
+func TestWatchRedialsFreshAfterReconnectAttemptsExhausted(t *testing.T) {
+	server := newWatchStubServer(t)
+
+	host, port := splitAddr(t, server.addr())
+	client := NewWithTimeout(host, port, time.Second)
+	require.NoError(t, client.Connect())
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.Watch(ctx, "")
+	require.NoError(t, err)
+
+	// Kill the server outright so every reconnect attempt fails and the
+	// broadcaster gives up for good.
+	server.closeAll()
+
+	select {
+	case _, ok := <-events:
+		assert.False(t, ok, "channel should be closed once reconnect attempts are exhausted")
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the dead broadcaster's channel to close")
+	}
+
+	// The next Watch call must surface a fresh dial error rather than
+	// silently handing back a subscription on the now-dead broadcaster.
+	_, err = client.Watch(ctx, "")
+	assert.Error(t, err)
+}
+
+func TestParseWatchEvent(t *testing.T) {
+	ev, ok := parseWatchEvent("EVENT SET key1 value1 42\r\n")
+	require.True(t, ok)
+	assert.Equal(t, WatchEvent{Op: "SET", Key: "key1", Value: "value1", Revision: 42}, ev)
+
+	_, ok = parseWatchEvent("NOT_AN_EVENT\r\n")
+	assert.False(t, ok)
+}
+
+func splitAddr(t *testing.T, addr string) (string, int) {
+	host, port, err := splitHostPort(addr)
+	require.NoError(t, err)
+	return host, port
+}